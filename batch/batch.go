@@ -0,0 +1,212 @@
+// Package batch decodes directory trees and archives of EEPROM dumps using a pool of workers.
+package batch
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/mickep76/go-sff"
+)
+
+// Result is a decoded module or the error for a source. Sources in archives are named
+// "archive.tar.gz:path/in/archive".
+type Result struct {
+	Source string      `json:"source"`
+	Format Format      `json:"format,omitempty"`
+	Module *sff.Module `json:"module,omitempty"`
+	Eeprom []byte      `json:"-"`
+	Err    error       `json:"-"`
+}
+
+// Error is a source that failed to read or decode.
+type Error struct {
+	Source string `json:"source"`
+	Err    string `json:"error"`
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Source, e.Err)
+}
+
+// Summary counts the sources, decoded modules and errors.
+type Summary struct {
+	Sources int     `json:"sources"`
+	Decoded int     `json:"decoded"`
+	Failed  int     `json:"failed"`
+	Errors  []Error `json:"errors"`
+}
+
+func (s *Summary) String() string {
+	str := fmt.Sprintf("%-50s : %d\n", "Sources", s.Sources) +
+		fmt.Sprintf("%-50s : %d\n", "Decoded", s.Decoded) +
+		fmt.Sprintf("%-50s : %d\n", "Failed", s.Failed)
+	for _, e := range s.Errors {
+		str += fmt.Sprintf("%-50s : %s\n", e.Source, e.Err)
+	}
+	return str
+}
+
+// Decoder walks paths and decodes the files with a bounded number of workers.
+type Decoder struct {
+	// Workers is the number of files decoded in parallel, defaults to the number of CPUs.
+	Workers int
+}
+
+type job struct {
+	n      int
+	source string
+	data   []byte
+	err    error
+}
+
+// Decode walks the paths, which can be files, directories or tar archives optionally gzip
+// compressed, and calls fn for each source in walk order. fn isn't called concurrently.
+func (d *Decoder) Decode(paths []string, fn func(Result)) *Summary {
+	workers := d.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	jobs := make(chan job, workers)
+	results := make(chan struct {
+		n int
+		r Result
+	}, workers)
+
+	go func() {
+		n := 0
+		for _, p := range paths {
+			walk(p, func(source string, data []byte, err error) {
+				jobs <- job{n, source, data, err}
+				n++
+			})
+		}
+		close(jobs)
+	}()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				r := Result{Source: j.source, Err: j.err}
+				if r.Err == nil {
+					r.Module, r.Eeprom, r.Format, r.Err = Parse(j.data)
+				}
+				results <- struct {
+					n int
+					r Result
+				}{j.n, r}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Results are reordered to walk order before calling fn.
+	s := &Summary{Errors: []Error{}}
+	pending := map[int]Result{}
+	next := 0
+	for res := range results {
+		pending[res.n] = res.r
+		for r, ok := pending[next]; ok; r, ok = pending[next] {
+			delete(pending, next)
+			next++
+
+			s.Sources++
+			if r.Err != nil {
+				s.Failed++
+				s.Errors = append(s.Errors, Error{Source: r.Source, Err: r.Err.Error()})
+			} else {
+				s.Decoded++
+			}
+			fn(r)
+		}
+	}
+	return s
+}
+
+// walk calls fn for each file in path, directories are walked in lexical order and archive
+// members in archive order.
+func walk(path string, fn func(source string, data []byte, err error)) {
+	err := filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			fn(p, nil, err)
+			return nil
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			fn(p, nil, err)
+			return nil
+		}
+
+		if err := walkFile(p, b, fn); err != nil {
+			fn(p, nil, err)
+		}
+		return nil
+	})
+	if err != nil {
+		fn(path, nil, err)
+	}
+}
+
+func isGzip(b []byte) bool {
+	return len(b) > 2 && b[0] == 0x1f && b[1] == 0x8b
+}
+
+func isTar(b []byte) bool {
+	return len(b) > 262 && string(b[257:262]) == "ustar"
+}
+
+// walkFile decompresses gzip and walks tar archives, otherwise the file is a single dump.
+func walkFile(path string, b []byte, fn func(source string, data []byte, err error)) error {
+	if isGzip(b) {
+		z, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		defer z.Close()
+
+		if b, err = ioutil.ReadAll(z); err != nil {
+			return err
+		}
+	}
+
+	if !isTar(b) {
+		fn(path, b, nil)
+		return nil
+	}
+
+	t := tar.NewReader(bytes.NewReader(b))
+	for {
+		h, err := t.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(t)
+		fn(path+":"+h.Name, data, err)
+	}
+}
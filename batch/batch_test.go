@@ -0,0 +1,270 @@
+package batch
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mickep76/go-sff"
+	"gopkg.in/yaml.v3"
+)
+
+// sfp returns an SFP A0h page.
+func sfp() []byte {
+	b := make([]byte, 256)
+	b[0], b[1], b[2] = 0x03, 0x04, 0x07
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	return b
+}
+
+// qsfpPlus returns a QSFP+ lower page and page 00h, the first byte 0x0D is a carriage return.
+func qsfpPlus() []byte {
+	b := make([]byte, 256)
+	b[0], b[128], b[130] = 0x0D, 0x0D, 0x07
+	copy(b[148:164], "FINISAR CORP.   ")
+	copy(b[168:184], "FTL410QE2C      ")
+	copy(b[196:212], "ALA5678         ")
+	copy(b[212:220], "190101  ")
+	return b
+}
+
+func hexDump(b []byte) []byte {
+	s := ""
+	for i := 0; i < len(b); i += 16 {
+		s += hex.EncodeToString(b[i:i+16]) + "\n"
+	}
+	return []byte(s)
+}
+
+// ethtoolDump returns the "ethtool -m <if> hex on" format.
+func ethtoolDump(b []byte) []byte {
+	s := "Offset\t\tValues\n------\t\t------"
+	for i := 0; i < len(b); i += 16 {
+		s += fmt.Sprintf("\n0x%04x:\t\t% x", i, b[i:i+16])
+	}
+	return []byte(s + "\n")
+}
+
+func decode(t *testing.T, b []byte) *sff.Module {
+	t.Helper()
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func jsonDump(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	j, err := json.Marshal(decode(t, b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func yamlDump(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	y, err := yaml.Marshal(decode(t, b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return y
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		dump []byte
+		want Format
+	}{
+		{"json", jsonDump(t, sfp()), FormatJSON},
+		{"json indented", append([]byte("\n  "), jsonDump(t, sfp())...), FormatJSON},
+		{"yaml", yamlDump(t, sfp()), FormatYAML},
+		{"yaml document", []byte("---\ntype: SFF-8079\n"), FormatYAML},
+		{"yaml key", []byte("vendor:\n  value: FINISAR CORP.\n"), FormatYAML},
+		{"yaml url", []byte("http://example.com\n"), FormatBinary},
+		{"hex", hexDump(sfp()), FormatHex},
+		{"hex with spaces", []byte("03 04 07\n00 00\n"), FormatHex},
+		{"ethtool", ethtoolDump(sfp()), FormatEthtool},
+		{"ethtool offsets", []byte("0x0000: 03 04 07\n"), FormatEthtool},
+		{"binary sfp", sfp(), FormatBinary},
+		{"binary qsfp+", qsfpPlus(), FormatBinary},
+		{"binary qsfp+ hex bytes", append([]byte{0x0D, '0', '3', '\n'}, qsfpPlus()[4:]...), FormatBinary},
+		{"empty", []byte{}, FormatBinary},
+	}
+	for _, tt := range tests {
+		if got := DetectFormat(tt.dump); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		dump   []byte
+		format Format
+		eeprom []byte
+		vendor string
+	}{
+		{"binary sfp", sfp(), FormatBinary, sfp(), "FINISAR CORP."},
+		{"binary qsfp+", qsfpPlus(), FormatBinary, qsfpPlus(), "FINISAR CORP."},
+		{"hex", hexDump(sfp()), FormatHex, sfp(), "FINISAR CORP."},
+		{"hex qsfp+", hexDump(qsfpPlus()), FormatHex, qsfpPlus(), "FINISAR CORP."},
+		{"ethtool", ethtoolDump(sfp()), FormatEthtool, sfp(), "FINISAR CORP."},
+		{"json", jsonDump(t, sfp()), FormatJSON, sfp(), "FINISAR CORP."},
+		{"yaml", yamlDump(t, qsfpPlus()), FormatYAML, qsfpPlus(), "FINISAR CORP."},
+	}
+	for _, tt := range tests {
+		m, eeprom, f, err := Parse(tt.dump)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if f != tt.format {
+			t.Errorf("%s: got format %s, want %s", tt.name, f, tt.format)
+		}
+		if !bytes.Equal(eeprom, tt.eeprom) {
+			t.Errorf("%s: got eeprom\n%x\nwant\n%x", tt.name, eeprom, tt.eeprom)
+		}
+		if v := vendor(m); v != tt.vendor {
+			t.Errorf("%s: got vendor %q, want %q", tt.name, v, tt.vendor)
+		}
+	}
+
+	for name, dump := range map[string][]byte{
+		"invalid hex":      []byte("03 04 0\n"),
+		"invalid ethtool":  []byte("0x0000: 03 04 0g\n"),
+		"invalid json":     []byte("{\"type\": 1}"),
+		"unknown module":   bytes.Repeat([]byte{0xFF}, 256),
+		"truncated binary": sfp()[:64],
+	} {
+		if _, _, _, err := Parse(dump); err == nil {
+			t.Errorf("%s: got nil error", name)
+		}
+	}
+}
+
+func vendor(m *sff.Module) string {
+	switch {
+	case m.Sff8079 != nil:
+		return m.Sff8079.Vendor.String()
+	case m.Sff8636 != nil:
+		return m.Sff8636.Vendor.String()
+	}
+	return ""
+}
+
+// tarGz returns a gzip compressed tar archive with the files in order.
+func tarGz(t *testing.T, files ...interface{}) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	z := gzip.NewWriter(buf)
+	w := tar.NewWriter(z)
+	for i := 0; i < len(files); i += 2 {
+		name, b := files[i].(string), files[i+1].([]byte)
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(b)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"a.bin":        sfp(),
+		"b.hex":        hexDump(qsfpPlus()),
+		"c.txt":        ethtoolDump(sfp()),
+		"d.json":       jsonDump(t, qsfpPlus()),
+		"e.yaml":       yamlDump(t, sfp()),
+		"f/bad.bin":    []byte("not a dump"),
+		"g/dumps.tgz":  tarGz(t, "x/qsfp.bin", qsfpPlus(), "x/sfp.hex", hexDump(sfp())),
+		"h/broken.tgz": []byte{0x1f, 0x8b, 0x00},
+	}
+	for name, b := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []struct {
+		source string
+		format Format
+		failed bool
+	}{
+		{"a.bin", FormatBinary, false},
+		{"b.hex", FormatHex, false},
+		{"c.txt", FormatEthtool, false},
+		{"d.json", FormatJSON, false},
+		{"e.yaml", FormatYAML, false},
+		{"f/bad.bin", FormatBinary, true},
+		{"g/dumps.tgz:x/qsfp.bin", FormatBinary, false},
+		{"g/dumps.tgz:x/sfp.hex", FormatHex, false},
+		{"h/broken.tgz", "", true},
+	}
+
+	got := []Result{}
+	s := (&Decoder{Workers: 3}).Decode([]string{dir, filepath.Join(dir, "missing")}, func(r Result) {
+		got = append(got, r)
+	})
+
+	if len(got) != len(want)+1 {
+		t.Fatalf("got %d results, want %d", len(got), len(want)+1)
+	}
+	for i, w := range want {
+		r := got[i]
+		if src := strings.TrimPrefix(filepath.ToSlash(r.Source), filepath.ToSlash(dir)+"/"); src != w.source {
+			t.Errorf("result %d: got source %s, want %s", i, src, w.source)
+		}
+		if r.Format != w.format {
+			t.Errorf("%s: got format %q, want %q", w.source, r.Format, w.format)
+		}
+		if (r.Err != nil) != w.failed {
+			t.Errorf("%s: got error %v, want failed %v", w.source, r.Err, w.failed)
+		}
+		if !w.failed && r.Module == nil {
+			t.Errorf("%s: got no module", w.source)
+		}
+	}
+
+	if s.Sources != 10 || s.Decoded != 7 || s.Failed != 3 || len(s.Errors) != 3 {
+		t.Fatalf("got summary %+v, want 10 sources, 7 decoded and 3 failed", s)
+	}
+	for i, src := range []string{"f/bad.bin", "h/broken.tgz", "missing"} {
+		if e := s.Errors[i]; e.Source != filepath.Join(dir, src) || e.Err == "" {
+			t.Errorf("error %d: got %+v, want an error for %s", i, e, src)
+		}
+	}
+	if str := s.String(); !strings.Contains(str, "Failed") || !strings.Contains(str, filepath.Join(dir, "f/bad.bin")) {
+		t.Errorf("got summary text\n%s\nwithout the failed source", str)
+	}
+}
@@ -0,0 +1,23 @@
+package batch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// IsInterface returns true if name is a network interface.
+func IsInterface(name string) bool {
+	_, err := os.Stat(filepath.Join("/sys/class/net", name))
+	return err == nil
+}
+
+// ReadInterface reads the module EEPROM for a network interface using "ethtool -m".
+func ReadInterface(name string) ([]byte, error) {
+	b, err := exec.Command("ethtool", "-m", name, "raw", "on").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ethtool -m %s: %v", name, err)
+	}
+	return b, nil
+}
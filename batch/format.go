@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"gopkg.in/yaml.v3"
+)
+
+// Format of an EEPROM dump.
+type Format string
+
+const (
+	FormatBinary  = Format("binary")
+	FormatHex     = Format("hex")
+	FormatEthtool = Format("ethtool")
+	FormatJSON    = Format("json")
+	FormatYAML    = Format("yaml")
+)
+
+var (
+	ethtoolOffset = regexp.MustCompile(`^0x[0-9a-fA-F]+:`)
+	hexOnly       = regexp.MustCompile(`^[0-9a-fA-F\s]+$`)
+	yamlKey       = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*:(\s|$)`)
+)
+
+// isText returns false if the dump has control characters other than whitespace, EEPROMs
+// always have NUL bytes.
+func isText(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectFormat returns the format of the dump, binary if it isn't any of the text formats.
+// Binary dumps are detected before trimming whitespace, since a QSFP+ starts with 0x0D.
+func DetectFormat(b []byte) Format {
+	if !isText(b) {
+		return FormatBinary
+	}
+
+	t := bytes.TrimSpace(b)
+	switch {
+	case len(t) > 0 && t[0] == '{':
+		return FormatJSON
+	case ethtoolOffset.Match(t) || bytes.HasPrefix(t, []byte("Offset")):
+		return FormatEthtool
+	case bytes.HasPrefix(t, []byte("---")) || yamlKey.Match(t):
+		return FormatYAML
+	case len(t) > 0 && hexOnly.Match(t):
+		return FormatHex
+	}
+	return FormatBinary
+}
+
+// parseEthtool parses the "ethtool -m <if> hex on" dump format.
+func parseEthtool(b []byte) ([]byte, error) {
+	r := []byte{}
+	for _, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if !ethtoolOffset.MatchString(l) {
+			continue
+		}
+
+		h, err := hex.DecodeString(strings.Join(strings.Fields(l[strings.Index(l, ":")+1:]), ""))
+		if err != nil {
+			return nil, fmt.Errorf("decode ethtool hex: %v", err)
+		}
+		r = append(r, h...)
+	}
+	return r, nil
+}
+
+// Parse detects the format and decodes the module, it returns the EEPROM bytes which for JSON
+// and YAML are encoded from the module.
+func Parse(b []byte) (*sff.Module, []byte, Format, error) {
+	f := DetectFormat(b)
+	t := bytes.TrimSpace(b)
+
+	var eeprom []byte
+	var err error
+	switch f {
+	case FormatJSON, FormatYAML:
+		m := &sff.Module{}
+		if f == FormatJSON {
+			err = json.Unmarshal(t, m)
+		} else {
+			err = yaml.Unmarshal(t, m)
+		}
+		if err != nil {
+			return nil, nil, f, err
+		}
+		if eeprom, err = sff.Encode(m); err != nil {
+			return nil, nil, f, err
+		}
+		return m, eeprom, f, nil
+	case FormatEthtool:
+		if eeprom, err = parseEthtool(t); err != nil {
+			return nil, nil, f, err
+		}
+	case FormatHex:
+		if eeprom, err = hex.DecodeString(strings.Join(strings.Fields(string(t)), "")); err != nil {
+			return nil, nil, f, fmt.Errorf("decode hex: %v", err)
+		}
+	default:
+		eeprom = b
+	}
+
+	m, err := sff.Decode(eeprom)
+	if err != nil {
+		return nil, nil, f, err
+	}
+	return m, eeprom, f, nil
+}
@@ -0,0 +1,36 @@
+package batch
+
+import (
+	"io/ioutil"
+
+	"github.com/mickep76/go-sff"
+)
+
+// Source reads a module on demand, such as on each scrape or poll.
+type Source struct {
+	Name string
+	Read func() (*sff.Module, error)
+}
+
+// File returns a source reading a dump file or sysfs eeprom in any supported format.
+func File(path string) Source {
+	return Source{Name: path, Read: func() (*sff.Module, error) {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		m, _, _, err := Parse(b)
+		return m, err
+	}}
+}
+
+// Interface returns a source reading the module for a network interface using "ethtool -m".
+func Interface(name string) Source {
+	return Source{Name: name, Read: func() (*sff.Module, error) {
+		b, err := ReadInterface(name)
+		if err != nil {
+			return nil, err
+		}
+		return sff.Decode(b)
+	}}
+}
@@ -0,0 +1,61 @@
+// Command sff-exporter exports module diagnostics as Prometheus metrics.
+//
+// Modules are read on each scrape from interfaces using "ethtool -m" and from files such as
+// sysfs eeprom files or dumps.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mickep76/go-sff/batch"
+	"github.com/mickep76/go-sff/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// list splits a comma separated flag value.
+func list(s string) []string {
+	l := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			l = append(l, v)
+		}
+	}
+	return l
+}
+
+func main() {
+	listen := flag.String("listen", ":9692", "Address to listen on")
+	path := flag.String("path", "/metrics", "Path to expose metrics on")
+	interfaces := flag.String("interfaces", "", "Comma separated list of interfaces")
+	files := flag.String("files", "", "Comma separated list of files")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: sff-exporter [flags]\n\nflags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	sources := []batch.Source{}
+	for _, n := range list(*interfaces) {
+		sources = append(sources, batch.Interface(n))
+	}
+	for _, f := range list(*files) {
+		sources = append(sources, batch.File(f))
+	}
+	if len(sources) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(prometheus.NewCollector(sources...))
+
+	http.Handle(*path, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	log.Printf("listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
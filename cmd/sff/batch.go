@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mickep76/go-sff/batch"
+)
+
+func batchCmd(args []string) int {
+	fs := newFlagSet("batch")
+	o := newModuleOutput(fs)
+	workers := fs.Int("workers", 0, "Number of files decoded in parallel, defaults to the number of CPUs")
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+
+	recs := []record{}
+	s := (&batch.Decoder{Workers: *workers}).Decode(fs.Args(), func(r batch.Result) {
+		if r.Err == nil {
+			recs = append(recs, record{Source: r.Source, Key: "module", Value: r.Module, Text: r.Module.String(), Color: r.Module.StringCol()})
+		}
+	})
+
+	if err := o.writeModules(recs); err != nil {
+		return fatal(err)
+	}
+
+	fmt.Fprint(os.Stderr, s.String())
+	if s.Failed > 0 {
+		return exitWarning
+	}
+	return exitOK
+}
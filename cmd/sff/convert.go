@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/openconfig"
+	"gopkg.in/yaml.v3"
+)
+
+var convertFormats = map[string]string{
+	"hex":        ".hex",
+	"binary":     ".bin",
+	"ethtool":    ".txt",
+	"json":       ".json",
+	"yaml":       ".yaml",
+	"openconfig": ".openconfig.json",
+}
+
+func convert(s *source, to string) ([]byte, error) {
+	switch to {
+	case "hex":
+		return []byte(hex.EncodeToString(s.Eeprom) + "\n"), nil
+	case "binary":
+		return s.Eeprom, nil
+	case "ethtool":
+		return []byte(hexDump(s.Eeprom, false)), nil
+	case "json":
+		b, err := json.MarshalIndent(s.Module, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	case "yaml":
+		return yaml.Marshal(s.Module)
+	case "openconfig":
+		b, err := openconfig.Marshal(map[string]*sff.Module{s.Name: s.Module})
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+	return nil, fmt.Errorf("unknown format: %s", to)
+}
+
+func convertCmd(args []string) int {
+	fs := newFlagSet("convert")
+	to := fs.String("to", "hex", "Output format: hex, binary, ethtool, json, yaml, openconfig")
+	dir := fs.String("output-dir", "", "Write each source to a file in this directory with the extension for the format")
+	fs.Parse(args)
+
+	ext, ok := convertFormats[*to]
+	if !ok {
+		return fatal(fmt.Errorf("unknown format: %s", *to))
+	}
+
+	if *dir == "" && fs.NArg() > 1 {
+		return fatal(fmt.Errorf("multiple sources requires --output-dir"))
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	for _, s := range srcs {
+		b, err := convert(s, *to)
+		if err != nil {
+			return fatal(fmt.Errorf("%s: %v", s.Name, err))
+		}
+
+		if *dir == "" {
+			if _, err := os.Stdout.Write(b); err != nil {
+				return fatal(err)
+			}
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(s.Name), filepath.Ext(s.Name))
+		if s.Name == "-" {
+			name = "stdin"
+		}
+		if err := ioutil.WriteFile(filepath.Join(*dir, name+ext), b, 0644); err != nil {
+			return fatal(err)
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/mickep76/go-sff"
+)
+
+// status returns the exit code for the alarm and warning flags.
+func status(m *sff.Module) int {
+	switch {
+	case len(m.Alarms()) > 0:
+		return exitAlarm
+	case len(m.Warnings()) > 0:
+		return exitWarning
+	}
+	return exitOK
+}
+
+func decodeCmd(args []string) int {
+	fs := newFlagSet("decode")
+	o := newModuleOutput(fs)
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	code := exitOK
+	recs := []record{}
+	for _, s := range srcs {
+		recs = append(recs, record{Source: s.Name, Key: "module", Value: s.Module, Text: s.Module.String(), Color: s.Module.StringCol()})
+		code = max(code, status(s.Module))
+	}
+
+	if err := o.writeModules(recs); err != nil {
+		return fatal(err)
+	}
+	return code
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mickep76/go-sff"
+)
+
+func diffCmd(args []string) int {
+	fs := newFlagSet("diff")
+	o := newOutput(fs)
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	if len(srcs) != 2 {
+		return fatal(fmt.Errorf("diff needs exactly two modules, got %d from %s", len(srcs), sourceArgs(fs.Args())))
+	}
+
+	d := sff.Diff(srcs[0].Module, srcs[1].Module)
+	name := fmt.Sprintf("%s %s", srcs[0].Name, srcs[1].Name)
+	if err := o.write([]record{{Source: name, Key: "differences", Value: d, Text: d.String(), Color: d.StringCol()}}); err != nil {
+		return fatal(err)
+	}
+
+	if len(d) > 0 {
+		return exitWarning
+	}
+	return exitOK
+}
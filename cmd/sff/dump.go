@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+type dumpLine struct {
+	Offset int    `json:"offset"`
+	Hex    string `json:"hex"`
+}
+
+// hexDump returns the bytes in the "ethtool -m <if> hex on" format.
+func hexDump(b []byte, color bool) string {
+	str := "Offset\t\tValues\n------\t\t------\n"
+	for o := 0; o < len(b); o += 16 {
+		l := ""
+		for i := o; i < o+16 && i < len(b); i++ {
+			l += fmt.Sprintf(" %02x", b[i])
+		}
+		if color {
+			str += fmt.Sprintf("%s0x%04x:%s\t\t%s\n", cyan, o, clear, l[1:])
+		} else {
+			str += fmt.Sprintf("0x%04x:\t\t%s\n", o, l[1:])
+		}
+	}
+	return str
+}
+
+func dumpCmd(args []string) int {
+	fs := newFlagSet("dump")
+	o := newOutput(fs)
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	recs := []record{}
+	for _, s := range srcs {
+		l := []dumpLine{}
+		for i := 0; i < len(s.Eeprom); i += 16 {
+			e := i + 16
+			if e > len(s.Eeprom) {
+				e = len(s.Eeprom)
+			}
+			l = append(l, dumpLine{Offset: i, Hex: hex.EncodeToString(s.Eeprom[i:e])})
+		}
+		recs = append(recs, record{Source: s.Name, Key: "lines", Value: l, Text: hexDump(s.Eeprom, false), Color: hexDump(s.Eeprom, true)})
+	}
+
+	if err := o.write(recs); err != nil {
+		return fatal(err)
+	}
+	return exitOK
+}
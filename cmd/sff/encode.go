@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func encodeCmd(args []string) int {
+	fs := newFlagSet("encode")
+	binary := fs.Bool("binary", false, "Output raw bytes instead of hex")
+	out := fs.String("output", "", "Write to file instead of stdout")
+	fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		return fatal(fmt.Errorf("encode takes a single source, got %d", fs.NArg()))
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	if len(srcs) != 1 {
+		return fatal(fmt.Errorf("encode needs exactly one module, got %d from %s", len(srcs), sourceArgs(fs.Args())))
+	}
+
+	b := srcs[0].Eeprom
+	if !*binary {
+		b = []byte(hex.EncodeToString(b) + "\n")
+	}
+
+	if *out != "" {
+		if err := ioutil.WriteFile(*out, b, 0644); err != nil {
+			return fatal(err)
+		}
+		return exitOK
+	}
+
+	if _, err := os.Stdout.Write(b); err != nil {
+		return fatal(err)
+	}
+	return exitOK
+}
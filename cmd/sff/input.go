@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/batch"
+)
+
+// source is a decoded module with the raw EEPROM bytes.
+type source struct {
+	Name   string
+	Eeprom []byte
+	Module *sff.Module
+}
+
+// readSource reads stdin for "-" or an interface using ethtool.
+func readSource(name string) ([]byte, error) {
+	if name == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+
+	if batch.IsInterface(name) {
+		return batch.ReadInterface(name)
+	}
+	return nil, fmt.Errorf("no such file or interface")
+}
+
+// sourceArgs returns the source arguments for error messages, stdin if there are none.
+func sourceArgs(names []string) string {
+	if len(names) == 0 {
+		return "stdin"
+	}
+	return strings.Join(names, ", ")
+}
+
+// loadSources reads and decodes the sources, stdin if there are none. Files, directories and
+// archives are decoded using batch.
+func loadSources(names []string) ([]*source, error) {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+
+	r := []*source{}
+	var first error
+	eachSource(names, func(s *source, err error) {
+		switch {
+		case err != nil && first == nil:
+			first = fmt.Errorf("%s: %v", s.Name, err)
+		case err == nil:
+			r = append(r, s)
+		}
+	})
+	if first != nil {
+		return nil, first
+	}
+	return r, nil
+}
+
+// eachSource reads and decodes the sources, fn is called with each module or the error for
+// the source that failed.
+func eachSource(names []string, fn func(s *source, err error)) {
+	for _, n := range names {
+		if _, err := os.Stat(n); err == nil && n != "-" {
+			(&batch.Decoder{}).Decode([]string{n}, func(res batch.Result) {
+				fn(&source{Name: res.Source, Eeprom: res.Eeprom, Module: res.Module}, res.Err)
+			})
+			continue
+		}
+
+		b, err := readSource(n)
+		if err != nil {
+			fn(&source{Name: n}, err)
+			continue
+		}
+
+		m, eeprom, _, err := batch.Parse(b)
+		fn(&source{Name: n, Eeprom: eeprom, Module: m}, err)
+	}
+}
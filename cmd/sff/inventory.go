@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mickep76/go-sff/inventory"
+)
+
+func inventoryCmd(args []string) int {
+	fs := newFlagSet("inventory")
+	o := newOutput(fs)
+	report := fs.Bool("report", false, "Output aggregated groups, age distribution, duplicate serials and revisions")
+	var group fieldList
+	fs.Var(&group, "group", "Group report by keys: vendor, vendorPn, vendorRev, formFactor, pmd, defaults to vendor,vendorPn")
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	keys := []inventory.Key{}
+	for _, g := range group {
+		k, err := inventory.ParseKey(g)
+		if err != nil {
+			return fatal(err)
+		}
+		keys = append(keys, k)
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	inv := inventory.New()
+	for _, s := range srcs {
+		inv.Add(s.Name, s.Module)
+	}
+
+	if *report {
+		r := inv.Report(time.Now(), keys...)
+		if (o.format == "csv" || o.format == "tsv") && len(o.fields) == 0 {
+			err = r.WriteCSV(o.w, o.comma())
+		} else {
+			err = o.write([]record{{Source: "inventory", Key: "report", Value: r, Text: r.String(), Color: r.StringCol()}})
+		}
+	} else {
+		switch {
+		case (o.format == "csv" || o.format == "tsv") && len(o.fields) == 0:
+			err = inv.WriteCSV(o.w, o.comma())
+		case (o.format == "text" || o.format == "color") && len(o.fields) == 0:
+			err = o.write([]record{{Source: "inventory", Text: inv.String(), Color: inv.StringCol()}})
+		default:
+			err = o.write([]record{{Source: "inventory", Key: "items", Value: inv.Items}})
+		}
+	}
+
+	if err != nil {
+		return fatal(fmt.Errorf("inventory: %v", err))
+	}
+	return exitOK
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/mickep76/go-sff/lint"
+)
+
+func lintCmd(args []string) int {
+	fs := newFlagSet("lint")
+	o := newOutput(fs)
+	var disable fieldList
+	fs.Var(&disable, "disable", "Disable rules by ID, can be repeated or comma separated")
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	srcs, err := loadSources(fs.Args())
+	if err != nil {
+		return fatal(err)
+	}
+
+	l := lint.New()
+	l.Configure(lint.Config{Disable: disable})
+
+	code := exitOK
+	recs := []record{}
+	for _, s := range srcs {
+		f := l.Lint(s.Module)
+		recs = append(recs, record{Source: s.Name, Key: "findings", Value: f, Text: f.String(), Color: f.StringCol()})
+
+		switch {
+		case len(f) == 0:
+		case f.Max() == lint.SeverityError:
+			code = max(code, exitAlarm)
+		case f.Max() == lint.SeverityWarning:
+			code = max(code, exitWarning)
+		}
+	}
+
+	if err := o.write(recs); err != nil {
+		return fatal(err)
+	}
+	return code
+}
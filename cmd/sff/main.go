@@ -0,0 +1,100 @@
+// Command sff decodes, validates and converts SFP and QSFP module EEPROMs.
+//
+// Sources are files with hex, ethtool hex dump, binary, JSON or YAML contents, sysfs eeprom
+// files, directories, tar archives, interface names read using "ethtool -m" or "-" for stdin.
+//
+// Exit codes follow the Nagios plugin convention: 0 OK, 1 warning, 2 alarm and 3 error.
+// Warnings are warning flags, lint warnings or differences, alarms are alarm flags or
+// lint errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/mickep76/go-sff/vendors/arista"
+)
+
+const (
+	exitOK = iota
+	exitWarning
+	exitAlarm
+	exitError
+)
+
+type command struct {
+	usage string
+	descr string
+	run   func(args []string) int
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"batch":     {"batch [flags] <path...>", "Decode directories and archives of dumps with an error summary", batchCmd},
+		"decode":    {"decode [flags] [source...]", "Decode modules", decodeCmd},
+		"dump":      {"dump [flags] [source...]", "Hex dump of the EEPROM", dumpCmd},
+		"diff":      {"diff [flags] <source a> <source b>", "Field level differences between two modules", diffCmd},
+		"lint":      {"lint [flags] [source...]", "Validate modules", lintCmd},
+		"encode":    {"encode [flags] [source]", "Encode a decoded JSON module to EEPROM bytes", encodeCmd},
+		"inventory": {"inventory [flags] [source...]", "Table of module identity fields", inventoryCmd},
+		"watch":     {"watch [flags] <source...>", "Poll modules and print changes", watchCmd},
+		"convert":   {"convert [flags] [source...]", "Convert between EEPROM formats", convertCmd},
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: sff <command> [flags] [source...]\n\ncommands:\n")
+	names := []string{}
+	for n := range commands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", n, commands[n].descr)
+	}
+	fmt.Fprintf(os.Stderr, "\nexit codes: 0 OK, 1 warning, 2 alarm, 3 error\n")
+}
+
+// newFlagSet returns a flag set for the command with usage.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: sff %s\n\nflags:\n", commands[name].usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// fatal prints the error and returns the error exit code.
+func fatal(err error) int {
+	fmt.Fprintf(os.Stderr, "sff: %v\n", err)
+	return exitError
+}
+
+// max returns the highest exit code.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func main() {
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		usage()
+		os.Exit(exitError)
+	}
+
+	c, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "sff: unknown command: %s\n\n", os.Args[1])
+		usage()
+		os.Exit(exitError)
+	}
+	os.Exit(c.run(os.Args[2:]))
+}
@@ -0,0 +1,439 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/flat"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cyan  = "\x1b[36m"
+	green = "\x1b[32m"
+	clear = "\x1b[0m"
+)
+
+var formats = []string{"text", "color", "json", "yaml", "csv", "tsv"}
+
+// fieldList is a comma separated flag that can be repeated.
+type fieldList []string
+
+func (f *fieldList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fieldList) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*f = append(*f, s)
+		}
+	}
+	return nil
+}
+
+type output struct {
+	format     string
+	fields     fieldList
+	columns    fieldList
+	valuesOnly bool
+	w          io.Writer
+}
+
+// newOutput registers the --format and --field flags.
+func newOutput(fs *flag.FlagSet) *output {
+	o := &output{w: os.Stdout}
+	fs.StringVar(&o.format, "format", "text", "Output format: "+strings.Join(formats, ", "))
+	fs.BoolVar(&o.valuesOnly, "values-only", false, "Output modules as plain values with unit suffixed keys instead of value, unit and hex")
+	fs.Var(&o.fields, "field", "Only output fields by JSON path, e.g. vendor or diagnostics.temperature, can be repeated or comma separated")
+	return o
+}
+
+// newModuleOutput registers the output flags and the --column flag for CSV and TSV module output.
+func newModuleOutput(fs *flag.FlagSet) *output {
+	o := newOutput(fs)
+	fs.Var(&o.columns, "column", "Only output flat columns for CSV and TSV, e.g. vendorPn or temperatureC, source is always the first column, can be repeated or comma separated")
+	return o
+}
+
+func (o *output) validate() error {
+	switch {
+	case len(o.columns) > 0 && len(o.fields) > 0:
+		return fmt.Errorf("--field and --column can't be combined, use --field for JSON paths or --column for flat columns")
+	case len(o.columns) > 0 && o.format != "csv" && o.format != "tsv":
+		return fmt.Errorf("--column only applies to csv and tsv output, use --field for %s output", o.format)
+	}
+
+	for _, f := range formats {
+		if o.format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format: %s", o.format)
+}
+
+// record is the output for a single source. Text and Color are used for text output without
+// fields, otherwise Value is output. A list Value is one CSV row per element, Key is the name
+// of the list when there are multiple sources.
+type record struct {
+	Source string
+	Key    string
+	Value  interface{}
+	Text   string
+	Color  string
+}
+
+// generic converts v to maps, lists and scalars using its JSON encoding.
+func (o *output) generic(v interface{}) (interface{}, error) {
+	var b []byte
+	var err error
+	if m, ok := v.(*sff.Module); ok && o.valuesOnly {
+		buf := &bytes.Buffer{}
+		enc := sff.NewEncoder(buf)
+		enc.SetValuesOnly(true)
+		err = enc.Encode(m)
+		b = buf.Bytes()
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var r interface{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// lookup returns the value for a dot separated path, list elements are selected by index.
+func lookup(v interface{}, path string) (interface{}, bool) {
+	for _, k := range strings.Split(path, ".") {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = t[k]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, false
+			}
+			v = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// display returns a value as text, using value or values and unit for encoded fields.
+func display(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case []interface{}:
+		l := []string{}
+		for _, e := range t {
+			l = append(l, display(e))
+		}
+		return strings.Join(l, ", ")
+	case map[string]interface{}:
+		s := ""
+		if val, ok := t["value"]; ok {
+			s = display(val)
+		} else if val, ok := t["values"]; ok {
+			s = display(val)
+		} else {
+			b, _ := json.Marshal(t)
+			return string(b)
+		}
+		if u, ok := t["unit"].(string); ok && s != "" {
+			s += " " + u
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// columns is the union of flattened keys in the order they were first seen.
+type columns struct {
+	keys []string
+	seen map[string]bool
+}
+
+func (c *columns) add(k string) {
+	if !c.seen[k] {
+		c.seen[k] = true
+		c.keys = append(c.keys, k)
+	}
+}
+
+// flatten returns leaf values keyed by path, encoded fields are leaves.
+func flatten(prefix string, v interface{}, cols *columns, out map[string]string) {
+	m, isMap := v.(map[string]interface{})
+	l, isList := v.([]interface{})
+	_, hasValue := m["value"]
+	_, hasValues := m["values"]
+
+	switch {
+	case isMap && !hasValue && !hasValues:
+		names := []string{}
+		for k := range m {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			flatten(join(prefix, k), m[k], cols, out)
+		}
+	case isList && len(l) > 0 && isObject(l[0]):
+		for i, e := range l {
+			flatten(join(prefix, strconv.Itoa(i)), e, cols, out)
+		}
+	default:
+		cols.add(prefix)
+		out[prefix] = display(v)
+	}
+}
+
+func isObject(v interface{}) bool {
+	_, ok := v.(map[string]interface{})
+	return ok
+}
+
+func join(prefix string, k string) string {
+	if prefix == "" {
+		return k
+	}
+	return prefix + "." + k
+}
+
+// selectFields returns the selected fields, v if no fields are selected.
+func (o *output) selectFields(v interface{}) interface{} {
+	if len(o.fields) == 0 {
+		return v
+	}
+
+	if l, ok := v.([]interface{}); ok {
+		r := []interface{}{}
+		for _, e := range l {
+			r = append(r, o.selectFields(e))
+		}
+		return r
+	}
+
+	m := map[string]interface{}{}
+	for _, f := range o.fields {
+		if val, ok := lookup(v, f); ok {
+			m[f] = val
+		}
+	}
+	return m
+}
+
+func (o *output) write(recs []record) error {
+	values := []interface{}{}
+	for _, r := range recs {
+		v, err := o.generic(r.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", r.Source, err)
+		}
+		values = append(values, o.selectFields(v))
+	}
+
+	switch o.format {
+	case "text", "color":
+		return o.writeText(recs, values)
+	case "json", "yaml":
+		return o.writeDoc(recs, values)
+	case "csv", "tsv":
+		return o.writeCSV(recs, values)
+	}
+	return fmt.Errorf("unknown format: %s", o.format)
+}
+
+func (o *output) writeText(recs []record, values []interface{}) error {
+	color := o.format == "color"
+	for i, r := range recs {
+		if len(recs) > 1 {
+			if i > 0 {
+				fmt.Fprintln(o.w)
+			}
+			if color {
+				fmt.Fprintf(o.w, "%s==> %s <==%s\n", cyan, r.Source, clear)
+			} else {
+				fmt.Fprintf(o.w, "==> %s <==\n", r.Source)
+			}
+		}
+
+		if len(o.fields) == 0 {
+			if color {
+				fmt.Fprint(o.w, r.Color)
+			} else {
+				fmt.Fprint(o.w, r.Text)
+			}
+			continue
+		}
+
+		l, ok := values[i].([]interface{})
+		if !ok {
+			l = []interface{}{values[i]}
+		}
+		for _, e := range l {
+			m, _ := e.(map[string]interface{})
+			for _, f := range o.fields {
+				v, ok := m[f]
+				if !ok {
+					continue
+				}
+				if color {
+					fmt.Fprintf(o.w, "%s%-50s%s : %s%s%s\n", cyan, f, clear, green, display(v), clear)
+				} else {
+					fmt.Fprintf(o.w, "%-50s : %s\n", f, display(v))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (o *output) writeDoc(recs []record, values []interface{}) error {
+	var doc interface{}
+	if len(recs) == 1 {
+		doc = values[0]
+	} else {
+		l := []interface{}{}
+		for i, r := range recs {
+			m, ok := values[i].(map[string]interface{})
+			if !ok {
+				m = map[string]interface{}{r.Key: values[i]}
+			}
+			m["source"] = r.Source
+			l = append(l, m)
+		}
+		doc = l
+	}
+
+	if o.format == "yaml" {
+		e := yaml.NewEncoder(o.w)
+		e.SetIndent(2)
+		if err := e.Encode(doc); err != nil {
+			return err
+		}
+		return e.Close()
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.w, "%s\n", b)
+	return err
+}
+
+// orderKeys orders flattened keys by the selected fields.
+func (o *output) orderKeys(keys []string) []string {
+	r := []string{}
+	for _, f := range o.fields {
+		for _, k := range keys {
+			if k == f || strings.HasPrefix(k, f+".") {
+				r = append(r, k)
+			}
+		}
+	}
+	return r
+}
+
+func (o *output) writeCSV(recs []record, values []interface{}) error {
+	cols := &columns{seen: map[string]bool{}}
+	rows := []map[string]string{}
+	for i, r := range recs {
+		l, ok := values[i].([]interface{})
+		if !ok {
+			l = []interface{}{values[i]}
+		}
+		for _, e := range l {
+			row := map[string]string{"source": r.Source}
+			flatten("", e, cols, row)
+			rows = append(rows, row)
+		}
+	}
+
+	keys := cols.keys
+	if len(o.fields) > 0 {
+		keys = o.orderKeys(keys)
+	}
+
+	w := csv.NewWriter(o.w)
+	w.Comma = o.comma()
+	if err := w.Write(append([]string{"source"}, keys...)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		l := []string{row["source"]}
+		for _, k := range keys {
+			l = append(l, row[k])
+		}
+		if err := w.Write(l); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (o *output) comma() rune {
+	if o.format == "tsv" {
+		return '\t'
+	}
+	return ','
+}
+
+// writeModules writes records with module values, CSV and TSV use the flat column set unless
+// fields are selected.
+func (o *output) writeModules(recs []record) error {
+	if (o.format != "csv" && o.format != "tsv") || len(o.fields) > 0 {
+		return o.write(recs)
+	}
+
+	cols, err := flat.ParseColumns(strings.Join(o.columns, ","))
+	if err != nil {
+		return err
+	}
+
+	if len(o.columns) > 0 {
+		l := []string{"source"}
+		for _, c := range cols {
+			if c != "source" {
+				l = append(l, c)
+			}
+		}
+		cols = l
+	}
+
+	w := flat.NewWriter(o.w)
+	w.Comma, w.Columns = o.comma(), cols
+	for _, r := range recs {
+		if err := w.Write(r.Source, r.Value.(*sff.Module)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mickep76/go-sff"
+)
+
+type event struct {
+	Time        time.Time       `json:"time"`
+	Error       string          `json:"error,omitempty"`
+	Alarms      []string        `json:"alarms"`
+	Warnings    []string        `json:"warnings"`
+	Differences sff.Differences `json:"differences"`
+}
+
+func (e event) text(color bool) string {
+	ts := e.Time.Format(time.RFC3339)
+	if color {
+		ts = cyan + ts + clear
+	}
+
+	str := ""
+	if e.Error != "" {
+		str += fmt.Sprintf("%s error: %s\n", ts, e.Error)
+	}
+	for _, a := range e.Alarms {
+		str += fmt.Sprintf("%s alarm: %s\n", ts, a)
+	}
+	for _, w := range e.Warnings {
+		str += fmt.Sprintf("%s warning: %s\n", ts, w)
+	}
+	for _, d := range e.Differences {
+		if color {
+			str += fmt.Sprintf("%s %s\n", ts, d.StringCol())
+		} else {
+			str += fmt.Sprintf("%s %s\n", ts, d.String())
+		}
+	}
+	return str
+}
+
+func watchCmd(args []string) int {
+	fs := newFlagSet("watch")
+	o := newOutput(fs)
+	interval := fs.Duration("interval", 10*time.Second, "Poll interval")
+	count := fs.Int("count", 0, "Stop after count polls, 0 runs until interrupted")
+	fs.Parse(args)
+	if err := o.validate(); err != nil {
+		return fatal(err)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError
+	}
+	for _, n := range fs.Args() {
+		if n == "-" {
+			return fatal(fmt.Errorf("watch can't poll stdin, use files, directories or interfaces"))
+		}
+	}
+
+	// A source that fails, such as a pulled module, is reported once and polled again.
+	prev := map[string]*sff.Module{}
+	failed := map[string]string{}
+	code := exitOK
+	for n := 0; *count == 0 || n < *count; n++ {
+		if n > 0 {
+			time.Sleep(*interval)
+		}
+
+		recs := []record{}
+		eachSource(fs.Args(), func(s *source, err error) {
+			if err != nil {
+				code = max(code, exitWarning)
+				if failed[s.Name] == err.Error() {
+					return
+				}
+				failed[s.Name] = err.Error()
+				e := event{Time: time.Now().UTC(), Error: err.Error(), Alarms: []string{}, Warnings: []string{}, Differences: sff.Differences{}}
+				recs = append(recs, record{Source: s.Name, Key: "event", Value: e, Text: e.text(false), Color: e.text(true)})
+				return
+			}
+
+			delete(failed, s.Name)
+			e := event{Time: time.Now().UTC(), Alarms: s.Module.Alarms(), Warnings: s.Module.Warnings(), Differences: sff.Differences{}}
+			if p, ok := prev[s.Name]; ok {
+				e.Differences = sff.Diff(p, s.Module)
+			}
+			prev[s.Name] = s.Module
+			code = max(code, status(s.Module))
+
+			if n > 0 && len(e.Differences) == 0 && len(e.Alarms) == 0 && len(e.Warnings) == 0 {
+				return
+			}
+			recs = append(recs, record{Source: s.Name, Key: "event", Value: e, Text: e.text(false), Color: e.text(true)})
+		})
+
+		if len(recs) == 0 {
+			continue
+		}
+		if err := o.write(recs); err != nil {
+			return fatal(err)
+		}
+	}
+	return code
+}
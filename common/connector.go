@@ -25,6 +25,12 @@ const (
 	ConnectorRj45        = 0x22
 	ConnectorNoSeparable = 0x23
 	ConnectorMxc2x16     = 0x24
+	ConnectorCs          = 0x25
+	ConnectorSn          = 0x26
+	ConnectorMpo2x12     = 0x27
+	ConnectorMpo1x16     = 0x28
+
+	ConnectorVendorSpecific = 0x80
 )
 
 var connectorNames = map[byte]string{
@@ -47,13 +53,25 @@ var connectorNames = map[byte]string{
 	ConnectorRj45:        "RJ45",
 	ConnectorNoSeparable: "No separable connector",
 	ConnectorMxc2x16:     "MXC 2x16",
+	ConnectorCs:          "CS optical connector",
+	ConnectorSn:          "SN (previously Mini CS) optical connector",
+	ConnectorMpo2x12:     "MPO 2x12",
+	ConnectorMpo1x16:     "MPO 1x16",
 }
 
 type Connector byte
 
+// VendorSpecific returns true for connectors in the vendor specific range 0x80-0xFF.
+func (c Connector) VendorSpecific() bool {
+	return byte(c) >= ConnectorVendorSpecific
+}
+
 func (c Connector) String() string {
 	n, ok := connectorNames[byte(c)]
 	if !ok {
+		if c.VendorSpecific() {
+			return "Vendor specific"
+		}
 		return "Reserved or unknown"
 	}
 	return n
@@ -82,3 +100,22 @@ func (c *Connector) UnmarshalJSON(in []byte) error {
 	*c = Connector(b[0])
 	return nil
 }
+
+// Optical returns true for optical connectors.
+func (c Connector) Optical() bool {
+	switch byte(c) {
+	case ConnectorSc, ConnectorFiberJack, ConnectorLc, ConnectorMtRj, ConnectorMu, ConnectorSg, ConnectorOptPtail,
+		ConnectorMpo, ConnectorMpo2, ConnectorMxc2x16, ConnectorCs, ConnectorSn, ConnectorMpo2x12, ConnectorMpo1x16:
+		return true
+	}
+	return false
+}
+
+// Copper returns true for copper connectors, a cable with no separable connector is neither copper nor optical.
+func (c Connector) Copper() bool {
+	switch byte(c) {
+	case ConnectorFcStyle1, ConnectorFcStyle2, ConnectorBncTnc, ConnectorFcCoax, ConnectorHssdcII, ConnectorCopperPtail, ConnectorRj45:
+		return true
+	}
+	return false
+}
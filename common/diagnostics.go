@@ -0,0 +1,158 @@
+package common
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Temperature in 1/256 degrees Celsius, signed two's complement.
+type Temperature [2]byte
+
+// Voltage in units of 100 uV.
+type Voltage [2]byte
+
+// Current in units of 2 uA.
+type Current [2]byte
+
+// Power in units of 0.1 uW.
+type Power [2]byte
+
+func floatToJSON(f float64, unit string, b []byte) ([]byte, error) {
+	m := map[string]interface{}{
+		"value": f,
+		"unit":  unit,
+		"hex":   hex.EncodeToString(b),
+	}
+	return json.Marshal(m)
+}
+
+func hexFromJSON(in []byte, n int, name string) ([]byte, error) {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := m["hex"].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing hex value for %s type", name)
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < n {
+		return nil, fmt.Errorf("length is shorter then %s type", name)
+	}
+	return b, nil
+}
+
+// Float64 returns the temperature in degrees Celsius.
+func (t Temperature) Float64() float64 {
+	return float64(int16(binary.BigEndian.Uint16(t[:]))) / 256
+}
+
+func (t Temperature) String() string {
+	return fmt.Sprintf("%.2f C", t.Float64())
+}
+
+func (t Temperature) MarshalJSON() ([]byte, error) {
+	return floatToJSON(t.Float64(), "C", t[:])
+}
+
+func (t *Temperature) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in, 2, "Temperature")
+	if err != nil {
+		return err
+	}
+
+	*t = Temperature{b[0], b[1]}
+	return nil
+}
+
+// Float64 returns the voltage in V.
+func (v Voltage) Float64() float64 {
+	return float64(binary.BigEndian.Uint16(v[:])) / 10000
+}
+
+func (v Voltage) String() string {
+	return fmt.Sprintf("%.4f V", v.Float64())
+}
+
+func (v Voltage) MarshalJSON() ([]byte, error) {
+	return floatToJSON(v.Float64(), "V", v[:])
+}
+
+func (v *Voltage) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in, 2, "Voltage")
+	if err != nil {
+		return err
+	}
+
+	*v = Voltage{b[0], b[1]}
+	return nil
+}
+
+// Float64 returns the current in mA.
+func (c Current) Float64() float64 {
+	return float64(binary.BigEndian.Uint16(c[:])) / 500
+}
+
+func (c Current) String() string {
+	return fmt.Sprintf("%.3f mA", c.Float64())
+}
+
+func (c Current) MarshalJSON() ([]byte, error) {
+	return floatToJSON(c.Float64(), "mA", c[:])
+}
+
+func (c *Current) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in, 2, "Current")
+	if err != nil {
+		return err
+	}
+
+	*c = Current{b[0], b[1]}
+	return nil
+}
+
+// Float64 returns the power in mW.
+func (p Power) Float64() float64 {
+	return float64(binary.BigEndian.Uint16(p[:])) / 10000
+}
+
+// Dbm returns the power in dBm, -Inf for no power.
+func (p Power) Dbm() float64 {
+	return 10 * math.Log10(p.Float64())
+}
+
+func (p Power) String() string {
+	return fmt.Sprintf("%.4f mW / %.2f dBm", p.Float64(), p.Dbm())
+}
+
+func (p Power) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": p.Float64(),
+		"unit":  "mW",
+		"hex":   hex.EncodeToString(p[:]),
+	}
+	if d := p.Dbm(); !math.IsInf(d, 0) {
+		m["dbm"] = d
+	}
+	return json.Marshal(m)
+}
+
+func (p *Power) UnmarshalJSON(in []byte) error {
+	b, err := hexFromJSON(in, 2, "Power")
+	if err != nil {
+		return err
+	}
+
+	*p = Power{b[0], b[1]}
+	return nil
+}
@@ -0,0 +1,222 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	ExtComplianceUnspecified    = 0x00
+	ExtCompliance100gAoc5e5     = 0x01
+	ExtCompliance100gSr4        = 0x02
+	ExtCompliance100gLr4        = 0x03
+	ExtCompliance100gEr4        = 0x04
+	ExtCompliance100gSr10       = 0x05
+	ExtCompliance100gCwdm4      = 0x06
+	ExtCompliance100gPsm4       = 0x07
+	ExtCompliance100gAcc5e5     = 0x08
+	ExtCompliance100gCwdm4NoFec = 0x09
+	ExtCompliance100gCr4        = 0x0B
+	ExtCompliance25gCrS         = 0x0C
+	ExtCompliance25gCrN         = 0x0D
+	ExtCompliance10mSpe         = 0x0E
+	ExtCompliance40gEr4         = 0x10
+	ExtCompliance4x10gSr        = 0x11
+	ExtCompliance40gPsm4        = 0x12
+	ExtComplianceG959P1i12d1    = 0x13
+	ExtComplianceG959P1s12d2    = 0x14
+	ExtComplianceG959P1l12d2    = 0x15
+	ExtCompliance10gTSfi        = 0x16
+	ExtCompliance100gClr4       = 0x17
+	ExtCompliance100gAoc1e12    = 0x18
+	ExtCompliance100gAcc1e12    = 0x19
+	ExtCompliance100gDwdm2      = 0x1A
+	ExtCompliance100gWdm        = 0x1B
+	ExtCompliance10gTSr         = 0x1C
+	ExtCompliance5gT            = 0x1D
+	ExtCompliance2g5T           = 0x1E
+	ExtCompliance40gSwdm4       = 0x1F
+	ExtCompliance100gSwdm4      = 0x20
+	ExtCompliance100gPam4Bidi   = 0x21
+	ExtCompliance4wdm10         = 0x22
+	ExtCompliance4wdm20         = 0x23
+	ExtCompliance4wdm40         = 0x24
+	ExtCompliance100gDr         = 0x25
+	ExtCompliance100gFr         = 0x26
+	ExtCompliance100gLr         = 0x27
+	ExtCompliance100gSr1Caui4   = 0x28
+	ExtCompliance400gSr4        = 0x29
+	ExtCompliance100gFr1        = 0x2A
+	ExtCompliance100gLr1        = 0x2B
+	ExtCompliance100gLr120Caui4 = 0x2C
+	ExtCompliance100gEr130Caui4 = 0x2D
+	ExtCompliance100gEr140Caui4 = 0x2E
+	ExtCompliance100gLr120      = 0x2F
+	ExtComplianceAcc50gAui1e6   = 0x30
+	ExtComplianceAoc50gAui1e6   = 0x31
+	ExtComplianceAcc50gAui2e4   = 0x32
+	ExtComplianceAoc50gAui2e4   = 0x33
+	ExtCompliance100gEr130      = 0x34
+	ExtCompliance100gEr140      = 0x35
+	ExtCompliance400gVr4        = 0x36
+	ExtCompliance10gBr          = 0x37
+	ExtCompliance25gBr          = 0x38
+	ExtCompliance50gBr          = 0x39
+	ExtCompliance100gVr1Caui4   = 0x3A
+	ExtCompliance400gCr4        = 0x3F
+	ExtCompliance50gCr          = 0x40
+	ExtCompliance50gSr          = 0x41
+	ExtCompliance50gFr          = 0x42
+	ExtCompliance200gFr4        = 0x43
+	ExtCompliance200gPsm4       = 0x44
+	ExtCompliance50gLr          = 0x45
+	ExtCompliance200gLr4        = 0x46
+	ExtCompliance400gDr4        = 0x47
+	ExtCompliance400gFr4        = 0x48
+	ExtCompliance400gLr46       = 0x49
+	ExtCompliance50gEr          = 0x4A
+	ExtCompliance400gLr410      = 0x4B
+	ExtCompliance400gZr         = 0x4C
+	ExtCompliance256gFcSw4      = 0x7F
+	ExtCompliance64gFc          = 0x80
+	ExtCompliance128gFc         = 0x81
+)
+
+var extComplianceNames = map[byte]string{
+	ExtComplianceUnspecified:    "Unspecified",
+	ExtCompliance100gAoc5e5:     "100G AOC or 25GAUI C2M AOC with worst BER of 5x10^(-5)",
+	ExtCompliance100gSr4:        "100GBASE-SR4 or 25GBASE-SR",
+	ExtCompliance100gLr4:        "100GBASE-LR4 or 25GBASE-LR",
+	ExtCompliance100gEr4:        "100GBASE-ER4 or 25GBASE-ER",
+	ExtCompliance100gSr10:       "100GBASE-SR10",
+	ExtCompliance100gCwdm4:      "100G CWDM4",
+	ExtCompliance100gPsm4:       "100G PSM4 Parallel SMF",
+	ExtCompliance100gAcc5e5:     "100G ACC or 25GAUI C2M ACC with worst BER of 5x10^(-5)",
+	ExtCompliance100gCwdm4NoFec: "Obsolete (assigned before 100G CWDM4 MSA required FEC)",
+	ExtCompliance100gCr4:        "100GBASE-CR4, 25GBASE-CR CA-25G-L or 50GBASE-CR2 with RS (Clause 91) FEC",
+	ExtCompliance25gCrS:         "25GBASE-CR CA-25G-S or 50GBASE-CR2 with BASE-R (Clause 74) FEC",
+	ExtCompliance25gCrN:         "25GBASE-CR CA-25G-N or 50GBASE-CR2 with no FEC",
+	ExtCompliance10mSpe:         "10 Mb/s Single Pair Ethernet (802.3cg, Clause 146/147, 1000 m copper)",
+	ExtCompliance40gEr4:         "40GBASE-ER4",
+	ExtCompliance4x10gSr:        "4 x 10GBASE-SR",
+	ExtCompliance40gPsm4:        "40G PSM4 Parallel SMF",
+	ExtComplianceG959P1i12d1:    "G959.1 profile P1I1-2D1 (10709 MBd, 2km, 1310 nm SM)",
+	ExtComplianceG959P1s12d2:    "G959.1 profile P1S1-2D2 (10709 MBd, 40km, 1550 nm SM)",
+	ExtComplianceG959P1l12d2:    "G959.1 profile P1L1-2D2 (10709 MBd, 80km, 1550 nm SM)",
+	ExtCompliance10gTSfi:        "10GBASE-T with SFI electrical interface",
+	ExtCompliance100gClr4:       "100G CLR4",
+	ExtCompliance100gAoc1e12:    "100G AOC or 25GAUI C2M AOC with worst BER of 10^(-12)",
+	ExtCompliance100gAcc1e12:    "100G ACC or 25GAUI C2M ACC with worst BER of 10^(-12)",
+	ExtCompliance100gDwdm2:      "100GE-DWDM2 (DWDM transceiver using 2 wavelengths on a 1550 nm DWDM grid with a reach up to 80 km)",
+	ExtCompliance100gWdm:        "100G 1550nm WDM (4 wavelengths)",
+	ExtCompliance10gTSr:         "10GBASE-T Short Reach (30 meters)",
+	ExtCompliance5gT:            "5GBASE-T",
+	ExtCompliance2g5T:           "2.5GBASE-T",
+	ExtCompliance40gSwdm4:       "40G SWDM4",
+	ExtCompliance100gSwdm4:      "100G SWDM4",
+	ExtCompliance100gPam4Bidi:   "100G PAM4 BiDi",
+	ExtCompliance4wdm10:         "4WDM-10 MSA (10km version of 100G CWDM4 with same RS(528,514) FEC in host system)",
+	ExtCompliance4wdm20:         "4WDM-20 MSA (20km version of 100GBASE-LR4 with RS(528,514) FEC in host system)",
+	ExtCompliance4wdm40:         "4WDM-40 MSA (40km reach with APD receiver and RS(528,514) FEC in host system)",
+	ExtCompliance100gDr:         "100GBASE-DR (Clause 140), CAUI-4 (no FEC)",
+	ExtCompliance100gFr:         "100G-FR or 100GBASE-FR1 (Clause 140), CAUI-4 (no FEC)",
+	ExtCompliance100gLr:         "100G-LR or 100GBASE-LR1 (Clause 140), CAUI-4 (no FEC)",
+	ExtCompliance100gSr1Caui4:   "100GBASE-SR1 (802.3, Clause 167), CAUI-4 (no FEC)",
+	ExtCompliance400gSr4:        "100GBASE-SR1, 200GBASE-SR2 or 400GBASE-SR4 (802.3, Clause 167)",
+	ExtCompliance100gFr1:        "100GBASE-FR1 (802.3, Clause 140) or 400GBASE-DR4-2 (802.3, Clause 124)",
+	ExtCompliance100gLr1:        "100GBASE-LR1 (802.3, Clause 140)",
+	ExtCompliance100gLr120Caui4: "100G-LR1-20 MSA, CAUI-4 (no FEC)",
+	ExtCompliance100gEr130Caui4: "100G-ER1-30 MSA, CAUI-4 (no FEC)",
+	ExtCompliance100gEr140Caui4: "100G-ER1-40 MSA, CAUI-4 (no FEC)",
+	ExtCompliance100gLr120:      "100G-LR1-20 MSA",
+	ExtComplianceAcc50gAui1e6:   "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M, BER of 10^(-6) or below",
+	ExtComplianceAoc50gAui1e6:   "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M, BER of 10^(-6) or below",
+	ExtComplianceAcc50gAui2e4:   "Active Copper Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M, BER of 2.6x10^(-4) for ACC, 10^(-5) for AUI, or below",
+	ExtComplianceAoc50gAui2e4:   "Active Optical Cable with 50GAUI, 100GAUI-2 or 200GAUI-4 C2M, BER of 2.6x10^(-4) for AOC, 10^(-5) for AUI, or below",
+	ExtCompliance100gEr130:      "100G-ER1-30 MSA",
+	ExtCompliance100gEr140:      "100G-ER1-40 MSA",
+	ExtCompliance400gVr4:        "100GBASE-VR1, 200GBASE-VR2 or 400GBASE-VR4 (802.3, Clause 167)",
+	ExtCompliance10gBr:          "10GBASE-BR (Clause 158)",
+	ExtCompliance25gBr:          "25GBASE-BR (Clause 159)",
+	ExtCompliance50gBr:          "50GBASE-BR (Clause 160)",
+	ExtCompliance100gVr1Caui4:   "100GBASE-VR1 (802.3, Clause 167), CAUI-4 (no FEC)",
+	ExtCompliance400gCr4:        "100GBASE-CR1, 200GBASE-CR2 or 400GBASE-CR4 (802.3, Clause 162)",
+	ExtCompliance50gCr:          "50GBASE-CR, 100GBASE-CR2, or 200GBASE-CR4",
+	ExtCompliance50gSr:          "50GBASE-SR, 100GBASE-SR2, or 200GBASE-SR4",
+	ExtCompliance50gFr:          "50GBASE-FR or 200GBASE-DR4",
+	ExtCompliance200gFr4:        "200GBASE-FR4",
+	ExtCompliance200gPsm4:       "200G 1550 nm PSM4",
+	ExtCompliance50gLr:          "50GBASE-LR",
+	ExtCompliance200gLr4:        "200GBASE-LR4",
+	ExtCompliance400gDr4:        "400GBASE-DR4 (802.3, Clause 124), 100GAUI-1 C2M (Annex 120G)",
+	ExtCompliance400gFr4:        "400GBASE-FR4 (802.3, Clause 151)",
+	ExtCompliance400gLr46:       "400GBASE-LR4-6 (802.3, Clause 151)",
+	ExtCompliance50gEr:          "50GBASE-ER (Clause 139)",
+	ExtCompliance400gLr410:      "400G-LR4-10",
+	ExtCompliance400gZr:         "400GBASE-ZR (802.3, Clause 156)",
+	ExtCompliance256gFcSw4:      "256GFC-SW4 (FC-PI-7P)",
+	ExtCompliance64gFc:          "64GFC (FC-PI-7)",
+	ExtCompliance128gFc:         "128GFC (FC-PI-8)",
+}
+
+// ExtCompliance is the SFF-8024 extended specification compliance code,
+// SFF-8472 byte 36 and SFF-8636 byte 192.
+type ExtCompliance byte
+
+func (e ExtCompliance) String() string {
+	n, ok := extComplianceNames[byte(e)]
+	if !ok {
+		return "Reserved or unknown"
+	}
+	return n
+}
+
+func (e ExtCompliance) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": e.String(),
+		"hex":   hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *ExtCompliance) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*e = ExtCompliance(b[0])
+	return nil
+}
+
+// Copper returns true for copper cables and BASE-T.
+func (e ExtCompliance) Copper() bool {
+	switch byte(e) {
+	case ExtCompliance100gAcc5e5, ExtCompliance100gCr4, ExtCompliance25gCrS, ExtCompliance25gCrN, ExtCompliance10mSpe,
+		ExtCompliance10gTSfi, ExtCompliance100gAcc1e12, ExtCompliance10gTSr, ExtCompliance5gT, ExtCompliance2g5T,
+		ExtComplianceAcc50gAui1e6, ExtComplianceAcc50gAui2e4, ExtCompliance400gCr4, ExtCompliance50gCr:
+		return true
+	}
+	return false
+}
+
+// ActiveOpticalCable returns true for active optical cables.
+func (e ExtCompliance) ActiveOpticalCable() bool {
+	switch byte(e) {
+	case ExtCompliance100gAoc5e5, ExtCompliance100gAoc1e12, ExtComplianceAoc50gAui1e6, ExtComplianceAoc50gAui2e4:
+		return true
+	}
+	return false
+}
+
+// Optical returns true for optical transceivers with a separable connector.
+func (e ExtCompliance) Optical() bool {
+	_, ok := extComplianceNames[byte(e)]
+	return ok && e != ExtComplianceUnspecified && !e.Copper() && !e.ActiveOpticalCable()
+}
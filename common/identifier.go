@@ -30,6 +30,22 @@ const (
 	IdentifierHd8xFanout = 0x15
 	IdentifierCdfpStyle3 = 0x16
 	IdentifierMicroQsfp  = 0x17
+	IdentifierQsfpDd     = 0x18
+	IdentifierOsfp       = 0x19
+	IdentifierSfpDd      = 0x1A
+	IdentifierDsfp       = 0x1B
+	IdentifierMiniLink4x = 0x1C
+	IdentifierMiniLink8x = 0x1D
+	IdentifierQsfpCmis   = 0x1E
+	IdentifierSfpDdCmis  = 0x1F
+	IdentifierSfpCmis    = 0x20
+	IdentifierOsfpXdCmis = 0x21
+	IdentifierElsfpCmis  = 0x22
+	IdentifierCdfpX4Cmis = 0x23
+	IdentifierCdfpX8Cmis = 0x24
+	IdentifierCdfpX16    = 0x25
+
+	IdentifierVendorSpecific = 0x80
 )
 
 var identifierNames = map[byte]string{
@@ -57,13 +73,35 @@ var identifierNames = map[byte]string{
 	IdentifierHd8xFanout: "Shielded Mini Multilane HD 8X Fanout Cable",
 	IdentifierCdfpStyle3: "CDFP Style 3",
 	IdentifierMicroQsfp:  "MicroQSFP",
+	IdentifierQsfpDd:     "QSFP-DD Double Density 8X Pluggable Transceiver",
+	IdentifierOsfp:       "OSFP 8X Pluggable Transceiver",
+	IdentifierSfpDd:      "SFP-DD Double Density 2X Pluggable Transceiver",
+	IdentifierDsfp:       "DSFP Dual Small Form Factor Pluggable Transceiver",
+	IdentifierMiniLink4x: "x4 MiniLink/OcuLink",
+	IdentifierMiniLink8x: "x8 MiniLink",
+	IdentifierQsfpCmis:   "QSFP+ or later with CMIS (QSFP112)",
+	IdentifierSfpDdCmis:  "SFP-DD Double Density 2X Pluggable Transceiver with CMIS",
+	IdentifierSfpCmis:    "SFP+ and later with CMIS (SFP112)",
+	IdentifierOsfpXdCmis: "OSFP-XD with CMIS",
+	IdentifierElsfpCmis:  "OIF-ELSFP with CMIS",
+	IdentifierCdfpX4Cmis: "CDFP (x4 PCIe) SFF-TA-1032 with CMIS",
+	IdentifierCdfpX8Cmis: "CDFP (x8 PCIe) SFF-TA-1032 with CMIS",
+	IdentifierCdfpX16:    "CDFP (x16 PCIe) SFF-TA-1032 with CMIS",
 }
 
 type Identifier byte
 
+// VendorSpecific returns true for identifiers in the vendor specific range 0x80-0xFF.
+func (i Identifier) VendorSpecific() bool {
+	return byte(i) >= IdentifierVendorSpecific
+}
+
 func (i Identifier) String() string {
 	s, ok := identifierNames[byte(i)]
 	if !ok {
+		if i.VendorSpecific() {
+			return "Vendor specific"
+		}
 		return "Reserved or unknown"
 	}
 	return s
@@ -0,0 +1,206 @@
+package common
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Lengths are saturated at 0xFF which means the supported link length is longer.
+type ValueM byte
+type Value2m byte
+type Value10m byte
+type Value100m byte
+type ValueKm byte
+
+func lengthString(b byte, mul uint) string {
+	if b == 0xFF {
+		return fmt.Sprintf("%d m or longer", uint(b)*mul)
+	}
+	return fmt.Sprintf("%d m", uint(b)*mul)
+}
+
+func lengthToJSON(b byte, mul uint) ([]byte, error) {
+	m := map[string]interface{}{
+		"value": uint(b) * mul,
+		"unit":  "m",
+		"hex":   hex.EncodeToString([]byte{b}),
+	}
+	if b == 0xFF {
+		m["orLonger"] = true
+	}
+	return json.Marshal(m)
+}
+
+func lengthFromJSON(in []byte) (byte, error) {
+	b, err := hexFromJSON(in, 1, "length")
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (v ValueM) Meters() uint {
+	return uint(v)
+}
+
+func (v ValueM) String() string {
+	return lengthString(byte(v), 1)
+}
+
+func (v ValueM) MarshalJSON() ([]byte, error) {
+	return lengthToJSON(byte(v), 1)
+}
+
+func (v *ValueM) UnmarshalJSON(in []byte) error {
+	b, err := lengthFromJSON(in)
+	*v = ValueM(b)
+	return err
+}
+
+func (v Value2m) Meters() uint {
+	return uint(v) * 2
+}
+
+func (v Value2m) String() string {
+	return lengthString(byte(v), 2)
+}
+
+func (v Value2m) MarshalJSON() ([]byte, error) {
+	return lengthToJSON(byte(v), 2)
+}
+
+func (v *Value2m) UnmarshalJSON(in []byte) error {
+	b, err := lengthFromJSON(in)
+	*v = Value2m(b)
+	return err
+}
+
+func (v Value10m) Meters() uint {
+	return uint(v) * 10
+}
+
+func (v Value10m) String() string {
+	return lengthString(byte(v), 10)
+}
+
+func (v Value10m) MarshalJSON() ([]byte, error) {
+	return lengthToJSON(byte(v), 10)
+}
+
+func (v *Value10m) UnmarshalJSON(in []byte) error {
+	b, err := lengthFromJSON(in)
+	*v = Value10m(b)
+	return err
+}
+
+func (v Value100m) Meters() uint {
+	return uint(v) * 100
+}
+
+func (v Value100m) String() string {
+	return lengthString(byte(v), 100)
+}
+
+func (v Value100m) MarshalJSON() ([]byte, error) {
+	return lengthToJSON(byte(v), 100)
+}
+
+func (v *Value100m) UnmarshalJSON(in []byte) error {
+	b, err := lengthFromJSON(in)
+	*v = Value100m(b)
+	return err
+}
+
+func (v ValueKm) Meters() uint {
+	return uint(v) * 1000
+}
+
+func (v ValueKm) String() string {
+	if v == 0xFF {
+		return fmt.Sprintf("%d km or longer", v)
+	}
+	return fmt.Sprintf("%d km", v)
+}
+
+func (v ValueKm) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": uint8(v),
+		"unit":  "km",
+		"hex":   hex.EncodeToString([]byte{byte(v)}),
+	}
+	if v == 0xFF {
+		m["orLonger"] = true
+	}
+	return json.Marshal(m)
+}
+
+func (v *ValueKm) UnmarshalJSON(in []byte) error {
+	b, err := lengthFromJSON(in)
+	*v = ValueKm(b)
+	return err
+}
+
+// Length is implemented by all length types.
+type Length interface {
+	fmt.Stringer
+	Meters() uint
+}
+
+const (
+	MediumSmf    = "SMF"
+	MediumOm1    = "OM1"
+	MediumOm2    = "OM2"
+	MediumOm3    = "OM3"
+	MediumOm4    = "OM4"
+	MediumCopper = "Copper"
+)
+
+// Reach is the supported link length for a medium.
+type Reach struct {
+	Medium   string `json:"medium"`
+	Meters   uint   `json:"meters"`
+	OrLonger bool   `json:"orLonger,omitempty"`
+}
+
+func (r Reach) String() string {
+	if r.OrLonger {
+		return fmt.Sprintf("%s %d m or longer", r.Medium, r.Meters)
+	}
+	return fmt.Sprintf("%s %d m", r.Medium, r.Meters)
+}
+
+// Reaches is a list of supported link lengths, see Add.
+type Reaches []Reach
+
+// Add a reach for the medium unless the length is zero, i.e. not supported or not specified.
+func (r Reaches) Add(medium string, l Length) Reaches {
+	if l.Meters() == 0 {
+		return r
+	}
+	return append(r, Reach{Medium: medium, Meters: l.Meters(), OrLonger: saturated(l)})
+}
+
+func saturated(l Length) bool {
+	switch v := l.(type) {
+	case ValueM:
+		return v == 0xFF
+	case Value2m:
+		return v == 0xFF
+	case Value10m:
+		return v == 0xFF
+	case Value100m:
+		return v == 0xFF
+	case ValueKm:
+		return v == 0xFF
+	}
+	return false
+}
+
+func (r Reaches) List() []string {
+	l := []string{}
+	for _, v := range r {
+		l = append(l, v.String())
+	}
+	return l
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+//go:generate go run oui_gen.go
+
+// Organization returns the IEEE registered organization for the OUI, only the vendors in
+// oui_vendors.txt are included.
+func (v VendorOUI) Organization() (string, bool) {
+	o, ok := ouiOrganizations[v.String()]
+	return o, ok
+}
+
+var vendorNames = map[string]string{
+	"ARISTA":    "Arista",
+	"AVAGO":     "Avago",
+	"BROCADE":   "Brocade",
+	"CISCO":     "Cisco",
+	"FINISAR":   "Finisar",
+	"INNOLIGHT": "Innolight",
+	"INTEL":     "Intel",
+	"JUNIPER":   "Juniper",
+	"MELLANOX":  "Mellanox",
+	"SUMITOMO":  "Sumitomo",
+}
+
+var vendorSuffixes = map[string]bool{
+	"CO":           true,
+	"CORP":         true,
+	"CORPORATE":    true,
+	"CORPORATION":  true,
+	"INC":          true,
+	"LLC":          true,
+	"LTD":          true,
+	"NETWORKS":     true,
+	"SYSTEMS":      true,
+	"TECHNOLOGIES": true,
+	"TECHNOLOGY":   true,
+}
+
+// NormalizeVendor returns a vendor name suitable for grouping, i.e. "FINISAR CORP." and
+// "Finisar" both return "Finisar". Unknown vendors are returned in title case without
+// company suffixes.
+func NormalizeVendor(name string) string {
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&' && r != '-'
+	})
+	if len(words) < 1 {
+		return ""
+	}
+
+	if n, ok := vendorNames[words[0]]; ok {
+		return n
+	}
+
+	for len(words) > 1 && vendorSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	for i, w := range words {
+		words[i] = w[:1] + strings.ToLower(w[1:])
+	}
+	return strings.Join(words, " ")
+}
+
+// VendorName returns the normalized vendor name, falling back to the organization for the OUI
+// if the name is empty.
+func VendorName(name String16, oui VendorOUI) string {
+	if n := NormalizeVendor(name.String()); n != "" {
+		return n
+	}
+	if o, ok := oui.Organization(); ok {
+		return NormalizeVendor(o)
+	}
+	return ""
+}
@@ -0,0 +1,123 @@
+//go:build ignore
+// +build ignore
+
+// Generates oui_table.go from the IEEE MA-L registry, filtered to the organizations listed in
+// oui_vendors.txt.
+//
+//	go run oui_gen.go [oui.csv]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+const registry = "https://standards-oui.ieee.org/oui/oui.csv"
+
+// vendors returns the organization name prefixes, lower case.
+func vendors(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := []string{}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" && !strings.HasPrefix(l, "#") {
+			r = append(r, strings.ToLower(l))
+		}
+	}
+	return r, s.Err()
+}
+
+func match(prefixes []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	prefixes, err := vendors("oui_vendors.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var r io.Reader
+	if len(os.Args) > 1 {
+		f, err := os.Open(os.Args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	} else {
+		resp, err := http.Get(registry)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", registry, resp.Status)
+		}
+		r = resp.Body
+	}
+
+	// Registry,Assignment,Organization Name,Organization Address
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	rows, err := c.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m := map[string]string{}
+	for _, row := range rows {
+		if len(row) < 3 || row[0] != "MA-L" || len(row[1]) != 6 || !match(prefixes, row[2]) {
+			continue
+		}
+		a := strings.ToLower(row[1])
+		m[a[0:2]+":"+a[2:4]+":"+a[4:6]] = strings.TrimSpace(row[2])
+	}
+	if len(m) == 0 {
+		log.Fatal("no matching organizations")
+	}
+
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// Code generated by oui_gen.go from the IEEE MA-L registry; DO NOT EDIT.\n\npackage common\n\n")
+	fmt.Fprintf(b, "// ouiOrganizations is the IEEE MA-L registry filtered to the vendors in oui_vendors.txt.\n")
+	fmt.Fprintf(b, "var ouiOrganizations = map[string]string{\n")
+	for _, k := range keys {
+		fmt.Fprintf(b, "\t%q: %q,\n", k, m[k])
+	}
+	fmt.Fprintf(b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("oui_table.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
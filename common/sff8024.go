@@ -0,0 +1,4 @@
+package common
+
+// Sff8024Revision is the revision of the SFF-8024 tables implemented by this library.
+const Sff8024Revision = "4.11"
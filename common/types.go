@@ -5,11 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 func stringToJSON(b []byte) ([]byte, error) {
 	m := map[string]interface{}{
-		"value": string(b),
+		"value": sanitizeASCII(b),
 		"hex":   hex.EncodeToString(b),
 	}
 	return json.Marshal(m)
@@ -19,8 +20,9 @@ type String2 [2]byte
 type String4 [4]byte
 type String16 [16]byte
 
+// String returns the string with padding trimmed and non-printable bytes replaced, see Validate.
 func (s String2) String() string {
-	return strings.TrimSpace(string([]byte(s[:2])))
+	return sanitizeASCII(s[:])
 }
 
 func (s String2) MarshalJSON() ([]byte, error) {
@@ -50,8 +52,9 @@ func (s *String2) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+// String returns the string with padding trimmed and non-printable bytes replaced, see Validate.
 func (s String4) String() string {
-	return strings.TrimSpace(string([]byte(s[:4])))
+	return sanitizeASCII(s[:])
 }
 
 func (s String4) MarshalJSON() ([]byte, error) {
@@ -81,8 +84,9 @@ func (s *String4) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+// String returns the string with padding trimmed and non-printable bytes replaced, see Validate.
 func (s String16) String() string {
-	return strings.TrimSpace(string([]byte(s[:16])))
+	return sanitizeASCII(s[:])
 }
 
 func (s String16) MarshalJSON() ([]byte, error) {
@@ -112,8 +116,6 @@ func (s *String16) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
-type ValueM byte
-type ValueKm byte
 type Value100Mbps byte
 type ValuePerc byte
 
@@ -126,54 +128,6 @@ func valueToJSON(b byte, unit string) ([]byte, error) {
 	return json.Marshal(m)
 }
 
-func (v ValueM) String() string {
-	return fmt.Sprintf("%d m", v)
-}
-
-func (v ValueM) MarshalJSON() ([]byte, error) {
-	return valueToJSON(byte(v), "m")
-}
-
-func (v *ValueM) UnmarshalJSON(in []byte) error {
-	m := map[string]interface{}{}
-	err := json.Unmarshal(in, &m)
-	if err != nil {
-		return err
-	}
-
-	b, err := hex.DecodeString(m["hex"].(string))
-	if err != nil {
-		return err
-	}
-
-	*v = ValueM(b[0])
-	return nil
-}
-
-func (v ValueKm) String() string {
-	return fmt.Sprintf("%d km", v)
-}
-
-func (v ValueKm) MarshalJSON() ([]byte, error) {
-	return valueToJSON(byte(v), "km")
-}
-
-func (v *ValueKm) UnmarshalJSON(in []byte) error {
-	m := map[string]interface{}{}
-	err := json.Unmarshal(in, &m)
-	if err != nil {
-		return err
-	}
-
-	b, err := hex.DecodeString(m["hex"].(string))
-	if err != nil {
-		return err
-	}
-
-	*v = ValueKm(b[0])
-	return nil
-}
-
 func (v Value100Mbps) String() string {
 	return fmt.Sprintf("%d Mb/s", uint(v)*100)
 }
@@ -230,7 +184,7 @@ func (v *ValuePerc) UnmarshalJSON(in []byte) error {
 type VendorOUI [3]byte
 
 func (v VendorOUI) String() string {
-	return fmt.Sprintf("%x:%x:%x", v[0], v[1], v[2])
+	return fmt.Sprintf("%02x:%02x:%02x", v[0], v[1], v[2])
 }
 
 func (v VendorOUI) MarshalJSON() ([]byte, error) {
@@ -238,6 +192,9 @@ func (v VendorOUI) MarshalJSON() ([]byte, error) {
 		"value": v.String(),
 		"hex":   hex.EncodeToString([]byte(v[:3])),
 	}
+	if o, ok := v.Organization(); ok {
+		m["organization"] = o
+	}
 	return json.Marshal(m)
 }
 
@@ -264,10 +221,41 @@ func (v *VendorOUI) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+// DateCode is the manufacturing date as ASCII YYMMDD followed by an optional
+// two character vendor lot code.
 type DateCode [8]byte
 
+// Time returns the date in UTC, year 00 is 2000. An error is returned for
+// non-numeric or impossible dates.
+func (d DateCode) Time() (time.Time, error) {
+	n := [3]int{}
+	for i := range n {
+		for _, c := range d[i*2 : i*2+2] {
+			if c < '0' || c > '9' {
+				return time.Time{}, fmt.Errorf("date code is not numeric: %q", string(d[:6]))
+			}
+			n[i] = n[i]*10 + int(c-'0')
+		}
+	}
+
+	t := time.Date(2000+n[0], time.Month(n[1]), n[2], 0, 0, 0, 0, time.UTC)
+	if int(t.Month()) != n[1] || t.Day() != n[2] {
+		return time.Time{}, fmt.Errorf("date code is not a valid date: %q", string(d[:6]))
+	}
+	return t, nil
+}
+
+// Lot returns the vendor specific lot code, bytes 6-7.
+func (d DateCode) Lot() string {
+	return strings.TrimSpace(string(d[6:8]))
+}
+
 func (d DateCode) String() string {
-	return fmt.Sprintf("20%s-%s-%s", string(d[:2]), string(d[2:4]), string(d[4:6]))
+	t, err := d.Time()
+	if err != nil {
+		return fmt.Sprintf("invalid (%q)", string(d[:6]))
+	}
+	return t.Format("2006-01-02")
 }
 
 func (d DateCode) MarshalJSON() ([]byte, error) {
@@ -275,6 +263,12 @@ func (d DateCode) MarshalJSON() ([]byte, error) {
 		"value": d.String(),
 		"hex":   hex.EncodeToString([]byte(d[:8])),
 	}
+	if t, err := d.Time(); err == nil {
+		m["date"] = t.Format(time.RFC3339)
+	}
+	if l := d.Lot(); l != "" {
+		m["lot"] = l
+	}
 	return json.Marshal(m)
 }
 
@@ -300,3 +294,131 @@ func (d *DateCode) UnmarshalJSON(in []byte) error {
 	}
 	return nil
 }
+
+type Value250Mbps byte
+
+func (v Value250Mbps) String() string {
+	return fmt.Sprintf("%d Mb/s", uint(v)*250)
+}
+
+func (v Value250Mbps) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": uint(v) * 250,
+		"unit":  "Mb/s",
+		"hex":   hex.EncodeToString([]byte{byte(v)}),
+	}
+	return json.Marshal(m)
+}
+
+func (v *Value250Mbps) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*v = Value250Mbps(b[0])
+	return nil
+}
+
+// Watts is a power consumption in W.
+type Watts float64
+
+func (w Watts) String() string {
+	return fmt.Sprintf("%.1f W", float64(w))
+}
+
+func (w Watts) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": float64(w),
+		"unit":  "W",
+	}
+	return json.Marshal(m)
+}
+
+func (w *Watts) UnmarshalJSON(in []byte) error {
+	m := struct {
+		Value float64 `json:"value"`
+	}{}
+	if err := json.Unmarshal(in, &m); err != nil {
+		return err
+	}
+
+	*w = Watts(m.Value)
+	return nil
+}
+
+// VendorSpec are the 32 vendor specific bytes, see package vendors for decoding.
+type VendorSpec [32]byte
+
+func (v VendorSpec) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"hex": hex.EncodeToString(v[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (v *VendorSpec) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 32 {
+		return fmt.Errorf("length is shorter then VendorSpec type")
+	}
+
+	*v = VendorSpec{}
+	copy(v[:], b)
+	return nil
+}
+
+// Checksum is the low order 8 bits of the sum of the bytes it covers.
+type Checksum byte
+
+// Calculate the checksum for b.
+func CalculateChecksum(b []byte) Checksum {
+	var c byte
+	for _, v := range b {
+		c += v
+	}
+	return Checksum(c)
+}
+
+func (c Checksum) String() string {
+	return fmt.Sprintf("0x%02x", byte(c))
+}
+
+func (c Checksum) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"hex": hex.EncodeToString([]byte{byte(c)}),
+	}
+	return json.Marshal(m)
+}
+
+func (c *Checksum) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*c = Checksum(b[0])
+	return nil
+}
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue is a problem found when validating a field, offset is the absolute byte offset.
+type Issue struct {
+	Field   string `json:"field"`
+	Offset  int    `json:"offset"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s [%d]: %s", i.Field, i.Offset, i.Message)
+}
+
+// StringError is returned in strict mode for strings that aren't space padded printable ASCII.
+type StringError struct {
+	Problems []string
+}
+
+func (e *StringError) Error() string {
+	return "invalid string: " + strings.Join(e.Problems, ", ")
+}
+
+func printable(c byte) bool {
+	return c >= 0x20 && c <= 0x7e
+}
+
+// checkASCII returns the problems with a space padded ASCII string, the offset is relative to b.
+func checkASCII(b []byte) []Issue {
+	r := []Issue{}
+
+	end := len(b)
+	for end > 0 && b[end-1] == 0x00 {
+		end--
+	}
+	switch {
+	case end == 0 && len(b) > 0:
+		r = append(r, Issue{Offset: 0, Message: "all NUL instead of space padded"})
+	case end < len(b):
+		r = append(r, Issue{Offset: end, Message: "NUL padded instead of space padded"})
+	}
+
+	if end > 0 && b[0] == ' ' && strings.TrimSpace(string(b[:end])) != "" {
+		r = append(r, Issue{Offset: 0, Message: "leading whitespace"})
+	}
+
+	for i, c := range b[:end] {
+		if !printable(c) {
+			r = append(r, Issue{Offset: i, Message: fmt.Sprintf("non-printable byte 0x%02x", c)})
+		}
+	}
+	return r
+}
+
+func validateASCII(b []byte) error {
+	l := checkASCII(b)
+	if len(l) == 0 {
+		return nil
+	}
+
+	e := &StringError{}
+	for _, i := range l {
+		e.Problems = append(e.Problems, fmt.Sprintf("%s at %d", i.Message, i.Offset))
+	}
+	return e
+}
+
+// sanitizeASCII trims space and NUL padding and replaces non-printable bytes with '?'.
+func sanitizeASCII(b []byte) string {
+	end := len(b)
+	for end > 0 && (b[end-1] == 0x00 || b[end-1] == ' ') {
+		end--
+	}
+
+	r := make([]byte, 0, end)
+	for _, c := range b[:end] {
+		if printable(c) {
+			r = append(r, c)
+		} else {
+			r = append(r, '?')
+		}
+	}
+	return strings.TrimSpace(string(r))
+}
+
+// LintASCII returns the issues for a string field at the absolute byte offset.
+func LintASCII(field string, offset int, b []byte) []Issue {
+	l := checkASCII(b)
+	for i := range l {
+		l[i].Field = field
+		l[i].Offset += offset
+	}
+	return l
+}
+
+// Validate returns an error if the string isn't space padded printable ASCII.
+func (s String2) Validate() error {
+	return validateASCII(s[:])
+}
+
+// Validate returns an error if the string isn't space padded printable ASCII.
+func (s String4) Validate() error {
+	return validateASCII(s[:])
+}
+
+// Validate returns an error if the string isn't space padded printable ASCII.
+func (s String16) Validate() error {
+	return validateASCII(s[:])
+}
@@ -0,0 +1,219 @@
+package common
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML returns the JSON encoding of v as a generic value, so YAML has the same shape as JSON.
+func MarshalYAML(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var r interface{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UnmarshalYAML decodes the YAML node into v using its JSON decoding.
+func UnmarshalYAML(n *yaml.Node, v interface{}) error {
+	var r interface{}
+	if err := n.Decode(&r); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (c Connector) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(c)
+}
+
+func (c *Connector) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, c)
+}
+
+func (t Temperature) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(t)
+}
+
+func (t *Temperature) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, t)
+}
+
+func (v Voltage) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Voltage) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (c Current) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(c)
+}
+
+func (c *Current) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, c)
+}
+
+func (p Power) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(p)
+}
+
+func (p *Power) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, p)
+}
+
+func (e ExtCompliance) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(e)
+}
+
+func (e *ExtCompliance) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, e)
+}
+
+func (i Identifier) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(i)
+}
+
+func (i *Identifier) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, i)
+}
+
+func (v ValueM) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *ValueM) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v Value2m) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Value2m) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v Value10m) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Value10m) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v Value100m) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Value100m) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v ValueKm) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *ValueKm) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (s String2) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(s)
+}
+
+func (s *String2) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, s)
+}
+
+func (s String4) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(s)
+}
+
+func (s *String4) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, s)
+}
+
+func (s String16) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(s)
+}
+
+func (s *String16) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, s)
+}
+
+func (v Value100Mbps) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Value100Mbps) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v ValuePerc) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *ValuePerc) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (v VendorOUI) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *VendorOUI) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (d DateCode) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(d)
+}
+
+func (d *DateCode) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, d)
+}
+
+func (v Value250Mbps) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *Value250Mbps) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (w Watts) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(w)
+}
+
+func (w *Watts) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, w)
+}
+
+func (v VendorSpec) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(v)
+}
+
+func (v *VendorSpec) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, v)
+}
+
+func (c Checksum) MarshalYAML() (interface{}, error) {
+	return MarshalYAML(c)
+}
+
+func (c *Checksum) UnmarshalYAML(n *yaml.Node) error {
+	return UnmarshalYAML(n, c)
+}
@@ -0,0 +1,291 @@
+// Package counterfeit scores modules for signs of recoded or cloned EEPROMs.
+package counterfeit
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+const (
+	red    = "\x1b[31m"
+	green  = "\x1b[32m"
+	yellow = "\x1b[33m"
+	cyan   = "\x1b[36m"
+	clear  = "\x1b[0m"
+)
+
+// Indicator IDs, DateIntroduced is opt-in and needs introduction dates in Detector.Introduced,
+// see LoadIntroduced.
+const (
+	OuiVendor       = "oui-vendor"
+	SerialReused    = "serial-reused"
+	DuplicateEeprom = "duplicate-eeprom"
+	DateIntroduced  = "date-introduced"
+	ChecksumFixup   = "checksum-fixup"
+	ChecksumInvalid = "checksum-invalid"
+)
+
+const (
+	maxScore         = 100
+	suspiciousScore  = 25
+	counterfeitScore = 50
+)
+
+// DefaultWeights is the score added for each indicator.
+var DefaultWeights = map[string]int{
+	OuiVendor:       30,
+	SerialReused:    40,
+	DuplicateEeprom: 50,
+	DateIntroduced:  30,
+	ChecksumFixup:   25,
+	ChecksumInvalid: 10,
+}
+
+// Port is a module and where it's installed, i.e. the host and interface name.
+type Port struct {
+	Name   string
+	Module *sff.Module
+}
+
+// Indicator is a suspicious pattern found in a module.
+type Indicator struct {
+	ID      string `json:"id"`
+	Score   int    `json:"score"`
+	Message string `json:"message"`
+}
+
+// Report is the indicators and total score for a port, the score is capped at 100.
+type Report struct {
+	Port       string      `json:"port"`
+	Vendor     string      `json:"vendor"`
+	VendorPn   string      `json:"vendorPn"`
+	VendorSn   string      `json:"vendorSn"`
+	Score      int         `json:"score"`
+	Verdict    string      `json:"verdict"`
+	Indicators []Indicator `json:"indicators"`
+}
+
+type Reports []Report
+
+// Detector holds the indicator weights and part number introduction dates.
+type Detector struct {
+	Weights map[string]int
+
+	// Introduced maps part number prefixes to when the part was introduced, the longest
+	// matching prefix is used. It's empty by default.
+	Introduced map[string]time.Time
+}
+
+// New returns a detector with the default weights.
+func New() *Detector {
+	w := map[string]int{}
+	for k, v := range DefaultWeights {
+		w[k] = v
+	}
+	return &Detector{Weights: w, Introduced: map[string]time.Time{}}
+}
+
+// LoadIntroduced adds part number prefixes and introduction dates from a JSON object, i.e.
+// {"FTLX8571D3BCL": "2009-06-01"}.
+func (d *Detector) LoadIntroduced(r io.Reader) error {
+	m := map[string]string{}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return fmt.Errorf("introduced: %v", err)
+	}
+
+	for pn, v := range m {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return fmt.Errorf("introduced: %s: %v", pn, err)
+		}
+		d.Introduced[pn] = t
+	}
+	return nil
+}
+
+// identity is the fields used to find modules across ports.
+type identity struct {
+	vendor   string
+	pn       string
+	sn       string
+	eeprom   [sha256.Size]byte
+	dateCode common.DateCode
+	ccBase   bool
+	ccExt    bool
+	oui      common.VendorOUI
+	rawName  string
+}
+
+func newIdentity(m *sff.Module) *identity {
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		return &identity{s.VendorName(), s.VendorPn.String(), s.VendorSn.String(), sha256.Sum256(s.Bytes()), s.DateCode,
+			s.CcBase == s.ChecksumBase(), s.CcExt == s.ChecksumExt(), s.VendorOui, s.Vendor.String()}
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		return &identity{s.VendorName(), s.VendorPn.String(), s.VendorSn.String(), sha256.Sum256(s.Bytes()), s.DateCode,
+			s.CcBase == s.ChecksumBase(), s.CcExt == s.ChecksumExt(), s.VendorOui, s.Vendor.String()}
+	}
+	return nil
+}
+
+func (i *identity) key() string {
+	return i.vendor + "\x00" + i.pn + "\x00" + i.sn
+}
+
+func (d *Detector) indicator(id string, format string, args ...interface{}) Indicator {
+	return Indicator{ID: id, Score: d.Weights[id], Message: fmt.Sprintf(format, args...)}
+}
+
+// introduced returns the introduction date for the longest matching part number prefix.
+func (d *Detector) introduced(pn string) (string, time.Time, bool) {
+	prefix, t := "", time.Time{}
+	for p, v := range d.Introduced {
+		if strings.HasPrefix(pn, p) && len(p) > len(prefix) {
+			prefix, t = p, v
+		}
+	}
+	return prefix, t, prefix != ""
+}
+
+// Detect scores each port, serial numbers and EEPROM contents are compared across all ports.
+func (d *Detector) Detect(ports []Port) Reports {
+	ids := make([]*identity, len(ports))
+	serials := map[string][]string{}
+	eeproms := map[[sha256.Size]byte][]string{}
+	for n, p := range ports {
+		if p.Module == nil {
+			continue
+		}
+		if ids[n] = newIdentity(p.Module); ids[n] == nil {
+			continue
+		}
+		if ids[n].sn != "" {
+			serials[ids[n].key()] = append(serials[ids[n].key()], p.Name)
+		}
+		eeproms[ids[n].eeprom] = append(eeproms[ids[n].eeprom], p.Name)
+	}
+
+	r := Reports{}
+	for n, p := range ports {
+		id := ids[n]
+		if id == nil {
+			continue
+		}
+
+		l := []Indicator{}
+		if o, ok := id.oui.Organization(); ok && id.rawName != "" && common.NormalizeVendor(o) != common.NormalizeVendor(id.rawName) {
+			l = append(l, d.indicator(OuiVendor, "OUI %s is registered to %s, vendor is %s", id.oui, o, id.rawName))
+		}
+
+		dup := others(eeproms[id.eeprom], p.Name)
+		if len(dup) > 0 {
+			l = append(l, d.indicator(DuplicateEeprom, "identical EEPROM in %s", strings.Join(dup, ", ")))
+		} else if o := others(serials[id.key()], p.Name); len(o) > 0 {
+			l = append(l, d.indicator(SerialReused, "serial number %s also used in %s", id.sn, strings.Join(o, ", ")))
+		}
+
+		if prefix, intro, ok := d.introduced(id.pn); ok {
+			if t, err := id.dateCode.Time(); err == nil && t.Before(intro) {
+				l = append(l, d.indicator(DateIntroduced, "date code %s is before %s was introduced %s", t.Format("2006-01-02"), prefix, intro.Format("2006-01-02")))
+			}
+		}
+
+		switch {
+		case id.ccBase != id.ccExt && id.ccBase:
+			l = append(l, d.indicator(ChecksumFixup, "CC_BASE is valid but CC_EXT isn't, the serial number or date code may have been rewritten"))
+		case id.ccBase != id.ccExt:
+			l = append(l, d.indicator(ChecksumFixup, "CC_EXT is valid but CC_BASE isn't, the vendor name or PN may have been rewritten"))
+		case !id.ccBase:
+			l = append(l, d.indicator(ChecksumInvalid, "CC_BASE and CC_EXT are both invalid"))
+		}
+
+		r = append(r, newReport(p.Name, id, l))
+	}
+	return r
+}
+
+// others returns the names except name, sorted.
+func others(names []string, name string) []string {
+	r := []string{}
+	for _, n := range names {
+		if n != name {
+			r = append(r, n)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+func newReport(port string, id *identity, l []Indicator) Report {
+	score := 0
+	for _, i := range l {
+		score += i.Score
+	}
+	if score > maxScore {
+		score = maxScore
+	}
+
+	v := "ok"
+	switch {
+	case score >= counterfeitScore:
+		v = "likely counterfeit"
+	case score >= suspiciousScore:
+		v = "suspicious"
+	}
+
+	return Report{Port: port, Vendor: id.vendor, VendorPn: id.pn, VendorSn: id.sn, Score: score, Verdict: v, Indicators: l}
+}
+
+func (r Report) key() string {
+	return fmt.Sprintf("%s %s %s %s", r.Port, r.Vendor, r.VendorPn, r.VendorSn)
+}
+
+func (r Report) String() string {
+	str := fmt.Sprintf("%-50s : %d (%s)\n", r.key(), r.Score, r.Verdict)
+	for _, i := range r.Indicators {
+		str += fmt.Sprintf("  %-48s : %s\n", fmt.Sprintf("%s +%d", i.ID, i.Score), i.Message)
+	}
+	return str
+}
+
+func (r Report) StringCol() string {
+	c := green
+	switch {
+	case r.Score >= counterfeitScore:
+		c = red
+	case r.Score >= suspiciousScore:
+		c = yellow
+	}
+
+	str := fmt.Sprintf("%s%-50s%s : %s%d (%s)%s\n", cyan, r.key(), clear, c, r.Score, r.Verdict, clear)
+	for _, i := range r.Indicators {
+		str += fmt.Sprintf("  %s%-48s%s : %s\n", cyan, fmt.Sprintf("%s +%d", i.ID, i.Score), clear, i.Message)
+	}
+	return str
+}
+
+func (r Reports) String() string {
+	str := ""
+	for _, v := range r {
+		str += v.String()
+	}
+	return str
+}
+
+func (r Reports) StringCol() string {
+	str := ""
+	for _, v := range r {
+		str += v.StringCol()
+	}
+	return str
+}
@@ -0,0 +1,148 @@
+package counterfeit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+// module returns a decoded SFF-8079 module with valid checksums.
+func module(t *testing.T, vendor string, oui common.VendorOUI, pn string, sn string, date string) *sff.Module {
+	t.Helper()
+
+	b := make([]byte, 256)
+	b[0], b[1] = 0x03, 0x04
+	copy(b[20:36], []byte(pad(vendor, 16)))
+	copy(b[37:40], oui[:])
+	copy(b[40:56], []byte(pad(pn, 16)))
+	copy(b[68:84], []byte(pad(sn, 16)))
+	copy(b[84:92], []byte(pad(date, 8)))
+	b[63] = byte(common.CalculateChecksum(b[0:63]))
+	b[95] = byte(common.CalculateChecksum(b[64:95]))
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func pad(s string, n int) string {
+	return s + strings.Repeat(" ", n-len(s))
+}
+
+func ids(r Report) []string {
+	l := []string{}
+	for _, i := range r.Indicators {
+		l = append(l, i.ID)
+	}
+	return l
+}
+
+func TestDetectOk(t *testing.T) {
+	m := module(t, "FINISAR CORP.", common.VendorOUI{0x00, 0x90, 0x65}, "FTLX8571D3BCL", "ALA1234", "190101")
+	r := New().Detect([]Port{{Name: "et1", Module: m}})
+	if len(r) != 1 {
+		t.Fatalf("got %d reports, want 1", len(r))
+	}
+	if r[0].Score != 0 || r[0].Verdict != "ok" || len(r[0].Indicators) != 0 {
+		t.Errorf("got score %d verdict %q indicators %v, want 0 ok []", r[0].Score, r[0].Verdict, ids(r[0]))
+	}
+}
+
+func TestDetectAcrossPorts(t *testing.T) {
+	oui := common.VendorOUI{0x00, 0x90, 0x65}
+	a := module(t, "FINISAR CORP.", oui, "FTLX8571D3BCL", "ALA1234", "190101")
+	b := module(t, "FINISAR CORP.", oui, "FTLX8571D3BCL", "ALA1234", "190101")
+	c := module(t, "FINISAR CORP.", oui, "FTLX8571D3BCL", "ALA1234", "190102")
+	d := module(t, "FINISAR CORP.", oui, "FTLX8571D3BCL", "ALA5678", "190101")
+
+	r := New().Detect([]Port{{Name: "et1", Module: a}, {Name: "et2", Module: b}, {Name: "et3", Module: c}, {Name: "et4", Module: d}, {Name: "et5"}})
+	if len(r) != 4 {
+		t.Fatalf("got %d reports, want 4", len(r))
+	}
+
+	tests := []struct {
+		port    string
+		ids     string
+		score   int
+		verdict string
+		message string
+	}{
+		{"et1", DuplicateEeprom, 50, "likely counterfeit", "identical EEPROM in et2"},
+		{"et2", DuplicateEeprom, 50, "likely counterfeit", "identical EEPROM in et1"},
+		{"et3", SerialReused, 40, "suspicious", "serial number ALA1234 also used in et1, et2"},
+		{"et4", "", 0, "ok", ""},
+	}
+	for n, tt := range tests {
+		if r[n].Port != tt.port {
+			t.Errorf("report %d: got port %s, want %s", n, r[n].Port, tt.port)
+		}
+		if got := strings.Join(ids(r[n]), ","); got != tt.ids {
+			t.Errorf("%s: got indicators %q, want %q", tt.port, got, tt.ids)
+		}
+		if r[n].Score != tt.score || r[n].Verdict != tt.verdict {
+			t.Errorf("%s: got %d (%s), want %d (%s)", tt.port, r[n].Score, r[n].Verdict, tt.score, tt.verdict)
+		}
+		if tt.message != "" && r[n].Indicators[0].Message != tt.message {
+			t.Errorf("%s: got message %q, want %q", tt.port, r[n].Indicators[0].Message, tt.message)
+		}
+	}
+}
+
+func TestDetectScore(t *testing.T) {
+	d := New()
+	if err := d.LoadIntroduced(strings.NewReader(`{"FTLX": "2010-01-01", "FTLX8571": "2015-01-01"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	arista := common.VendorOUI{0x00, 0x1c, 0x73}
+	finisar := common.VendorOUI{0x00, 0x90, 0x65}
+	fixup := module(t, "FINISAR CORP.", finisar, "FTLX1471D3BCL", "ALA0002", "190101")
+	fixup.Sff8079.CcExt++
+	invalid := module(t, "FINISAR CORP.", finisar, "FTLX1471D3BCL", "ALA0003", "190101")
+	invalid.Sff8079.CcBase++
+	invalid.Sff8079.CcExt++
+
+	tests := []struct {
+		name    string
+		module  *sff.Module
+		ids     string
+		score   int
+		verdict string
+	}{
+		{"oui", module(t, "FINISAR CORP.", arista, "FTLX1471D3BCL", "ALA0001", "190101"), OuiVendor, 30, "suspicious"},
+		{"date", module(t, "FINISAR CORP.", finisar, "FTLX8571D3BCL", "ALA0001", "140101"), DateIntroduced, 30, "suspicious"},
+		{"date prefix", module(t, "FINISAR CORP.", finisar, "FTLX1471D3BCL", "ALA0001", "140101"), "", 0, "ok"},
+		{"fixup", fixup, ChecksumFixup, 25, "suspicious"},
+		{"invalid", invalid, ChecksumInvalid, 10, "ok"},
+		{"capped", module(t, "FINISAR CORP.", arista, "FTLX8571D3BCL", "ALA0001", "140101"), OuiVendor + "," + DateIntroduced, 60, "likely counterfeit"},
+	}
+	for _, tt := range tests {
+		r := d.Detect([]Port{{Name: tt.name, Module: tt.module}})
+		if got := strings.Join(ids(r[0]), ","); got != tt.ids {
+			t.Errorf("%s: got indicators %q, want %q", tt.name, got, tt.ids)
+		}
+		if r[0].Score != tt.score || r[0].Verdict != tt.verdict {
+			t.Errorf("%s: got %d (%s), want %d (%s)", tt.name, r[0].Score, r[0].Verdict, tt.score, tt.verdict)
+		}
+	}
+
+	a := module(t, "FINISAR CORP.", arista, "FTLX8571D3BCL", "ALA0001", "140101")
+	b := module(t, "FINISAR CORP.", arista, "FTLX8571D3BCL", "ALA0001", "140101")
+	r := d.Detect([]Port{{Name: "et1", Module: a}, {Name: "et2", Module: b}})
+	if r[0].Score != maxScore {
+		t.Errorf("got score %d, want %d", r[0].Score, maxScore)
+	}
+}
+
+func TestLoadIntroduced(t *testing.T) {
+	if err := New().LoadIntroduced(strings.NewReader(`{"FTLX": "2010-13-01"}`)); err == nil {
+		t.Error("got nil error for invalid date")
+	}
+	if err := New().LoadIntroduced(strings.NewReader(`["FTLX"]`)); err == nil {
+		t.Error("got nil error for invalid JSON")
+	}
+}
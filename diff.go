@@ -0,0 +1,152 @@
+package sff
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+const (
+	red   = "\x1b[31m"
+	green = "\x1b[32m"
+	cyan  = "\x1b[36m"
+	clear = "\x1b[0m"
+)
+
+// Difference for a single field between two modules, offset is the byte offset within the page.
+type Difference struct {
+	Page   string `json:"page"`
+	Field  string `json:"field"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	OldHex string `json:"oldHex"`
+	NewHex string `json:"newHex"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+type Differences []Difference
+
+func (d Difference) key() string {
+	if d.Length == 0 {
+		return strings.TrimSpace(fmt.Sprintf("%s %s", d.Page, d.Field))
+	}
+	if d.Length == 1 {
+		return fmt.Sprintf("%s %s [%d]", d.Page, d.Field, d.Offset)
+	}
+	return fmt.Sprintf("%s %s [%d-%d]", d.Page, d.Field, d.Offset, d.Offset+d.Length-1)
+}
+
+func (d Difference) value(v string, h string) string {
+	if h == "" {
+		return v
+	}
+	if v == "" {
+		return "0x" + h
+	}
+	return fmt.Sprintf("%s (0x%s)", v, h)
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%-50s : %s -> %s", d.key(), d.value(d.Old, d.OldHex), d.value(d.New, d.NewHex))
+}
+
+func (d Difference) StringCol() string {
+	return fmt.Sprintf("%s%-50s%s : %s%s%s -> %s%s%s", cyan, d.key(), clear, red, d.value(d.Old, d.OldHex), clear, green, d.value(d.New, d.NewHex), clear)
+}
+
+func (d Differences) String() string {
+	str := ""
+	for _, v := range d {
+		str += v.String() + "\n"
+	}
+	return str
+}
+
+func (d Differences) StringCol() string {
+	str := ""
+	for _, v := range d {
+		str += v.StringCol() + "\n"
+	}
+	return str
+}
+
+// Diff returns the field level differences between module a and b.
+func Diff(a, b *Module) Differences {
+	if a.Type != b.Type {
+		return Differences{{Field: "Type", Old: string(a.Type), New: string(b.Type)}}
+	}
+
+	switch a.Type {
+	case TypeSff8079:
+		// The A2h values are calibrated for externally calibrated modules, the hex is raw.
+		return append(diffPage("A0h", 0, a.Sff8079, b.Sff8079), diffDecoded("A2h", 0, a.Sff8472, b.Sff8472, a.Diagnostics(), b.Diagnostics())...)
+	case TypeSff8636:
+		d := diffPage("Lower Page", 0, a.LowerPage, b.LowerPage)
+		d = append(d, diffPage("Page 00h", 128, a.Sff8636, b.Sff8636)...)
+		return append(d, diffPage("Page 03h", 128, a.Thresholds, b.Thresholds)...)
+	}
+	return Differences{}
+}
+
+func diffPage(page string, base int, a interface{}, b interface{}) Differences {
+	return diffDecoded(page, base, a, b, a, b)
+}
+
+// diffDecoded compares the raw pages a and b, the values are taken from the decoded pages da
+// and db of the same type.
+func diffDecoded(page string, base int, a interface{}, b interface{}, da interface{}, db interface{}) Differences {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case va.IsNil() && vb.IsNil():
+		return Differences{}
+	case va.IsNil():
+		return Differences{{Page: page, Old: "absent", New: "present"}}
+	case vb.IsNil():
+		return Differences{{Page: page, Old: "present", New: "absent"}}
+	}
+
+	t := va.Elem().Type()
+	n := int(t.Size())
+	ba := unsafe.Slice((*byte)(unsafe.Pointer(va.Pointer())), n)
+	bb := unsafe.Slice((*byte)(unsafe.Pointer(vb.Pointer())), n)
+	dva, dvb := reflect.ValueOf(da).Elem(), reflect.ValueOf(db).Elem()
+
+	d := Differences{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		o, l := int(f.Offset), int(f.Type.Size())
+		if string(ba[o:o+l]) == string(bb[o:o+l]) {
+			continue
+		}
+
+		d = append(d, Difference{
+			Page:   page,
+			Field:  f.Name,
+			Offset: base + o,
+			Length: l,
+			OldHex: hex.EncodeToString(ba[o : o+l]),
+			NewHex: hex.EncodeToString(bb[o : o+l]),
+			Old:    fieldString(dva.Field(i)),
+			New:    fieldString(dvb.Field(i)),
+		})
+	}
+	return d
+}
+
+func fieldString(v reflect.Value) string {
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return strings.Replace(s.String(), "\n", ", ", -1)
+	}
+
+	if v.Kind() == reflect.Array && v.Type().Elem().Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()) {
+		l := []string{}
+		for i := 0; i < v.Len(); i++ {
+			l = append(l, v.Index(i).Interface().(fmt.Stringer).String())
+		}
+		return strings.Join(l, ", ")
+	}
+	return ""
+}
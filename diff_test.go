@@ -0,0 +1,121 @@
+package sff
+
+import (
+	"testing"
+)
+
+// sfpDump returns an SFP with diagnostics, temperature 33.5 C and a temperature slope of 2
+// used when externally calibrated.
+func sfpDump() []byte {
+	b := make([]byte, 512)
+	b[0], b[1], b[92] = 0x03, 0x04, 0x60
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	b[256+84], b[256+85] = 0x02, 0x00
+	b[256+96], b[256+97] = 0x21, 0x80
+	return b
+}
+
+// qsfpDump returns a QSFP with the lower page, page 00h and page 03h.
+func qsfpDump() []byte {
+	b := make([]byte, 640)
+	b[0], b[128] = 0x11, 0x11
+	copy(b[148:164], "FINISAR CORP.   ")
+	copy(b[196:212], "ALA1234         ")
+	copy(b[212:220], "190101  ")
+	b[22], b[23] = 0x21, 0x80
+	return b
+}
+
+func decode(t *testing.T, b []byte) *Module {
+	t.Helper()
+
+	m, err := Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []byte
+		change func(b []byte)
+		want   []Difference
+	}{
+		{"equal", sfpDump(), sfpDump(), nil, nil},
+		{"A0h", sfpDump(), sfpDump(), func(b []byte) { copy(b[68:], "XYZ999 ") },
+			[]Difference{{Page: "A0h", Field: "VendorSn", Offset: 68, Length: 16,
+				OldHex: "414c4131323334202020202020202020", NewHex: "58595a39393920202020202020202020", Old: "ALA1234", New: "XYZ999"}}},
+		{"A2h", sfpDump(), sfpDump(), func(b []byte) { b[256+96] = 0x22 },
+			[]Difference{{Page: "A2h", Field: "Temperature", Offset: 96, Length: 2, OldHex: "2180", NewHex: "2280"}}},
+		{"A2h absent", sfpDump(), sfpDump()[:256], nil,
+			[]Difference{{Page: "A2h", Old: "present", New: "absent"}}},
+		{"A2h present", sfpDump()[:256], sfpDump(), nil,
+			[]Difference{{Page: "A2h", Old: "absent", New: "present"}}},
+		{"lower page", qsfpDump(), qsfpDump(), func(b []byte) { b[22] = 0x22 },
+			[]Difference{{Page: "Lower Page", Field: "Temperature", Offset: 22, Length: 2, OldHex: "2180", NewHex: "2280"}}},
+		{"page 00h", qsfpDump(), qsfpDump(), func(b []byte) { copy(b[196:], "XYZ999 ") },
+			[]Difference{{Page: "Page 00h", Field: "VendorSn", Offset: 196, Length: 16,
+				OldHex: "414c4131323334202020202020202020", NewHex: "58595a39393920202020202020202020", Old: "ALA1234", New: "XYZ999"}}},
+		{"page 03h", qsfpDump(), qsfpDump(), func(b []byte) { b[512] = 0x50 },
+			[]Difference{{Page: "Page 03h", Field: "TempHighAlarm", Offset: 128, Length: 2, OldHex: "0000", NewHex: "5000"}}},
+		{"page 03h absent", qsfpDump(), qsfpDump()[:256], nil,
+			[]Difference{{Page: "Page 03h", Old: "present", New: "absent"}}},
+		{"type", sfpDump(), qsfpDump(), nil,
+			[]Difference{{Field: "Type", Old: string(TypeSff8079), New: string(TypeSff8636)}}},
+	}
+	for _, tt := range tests {
+		if tt.change != nil {
+			tt.change(tt.b)
+		}
+		a, b := decode(t, tt.a), decode(t, tt.b)
+		d := Diff(a, b)
+		if len(d) != len(tt.want) {
+			t.Errorf("%s: got %d differences, want %d:\n%s", tt.name, len(d), len(tt.want), d)
+			continue
+		}
+		for i, w := range tt.want {
+			g := d[i]
+			if w.Old == "" && w.New == "" && w.Field != "" {
+				// Decoded values are checked in TestDiffCalibrated.
+				g.Old, g.New = "", ""
+			}
+			if g != w {
+				t.Errorf("%s: got %+v, want %+v", tt.name, g, w)
+			}
+		}
+	}
+}
+
+func TestDiffCalibrated(t *testing.T) {
+	for _, ext := range []bool{false, true} {
+		ba, bb := sfpDump(), sfpDump()
+		bb[256+96] = 0x22
+		if ext {
+			ba[92], bb[92] = 0x50, 0x50
+		}
+		a, b := decode(t, ba), decode(t, bb)
+
+		d := Diff(a, b)
+		if len(d) != 1 {
+			t.Fatalf("external %v: got %d differences, want 1:\n%s", ext, len(d), d)
+		}
+
+		old, new := a.Sff8472.Temperature.String(), b.Sff8472.Temperature.String()
+		if ext {
+			old, new = a.Diagnostics().Temperature.String(), b.Diagnostics().Temperature.String()
+			if old == a.Sff8472.Temperature.String() {
+				t.Fatalf("external %v: calibrated temperature %s is the raw value", ext, old)
+			}
+		}
+		if d[0].Old != old || d[0].New != new {
+			t.Errorf("external %v: got %s -> %s, want %s -> %s", ext, d[0].Old, d[0].New, old, new)
+		}
+		if d[0].OldHex != "2180" || d[0].NewHex != "2280" {
+			t.Errorf("external %v: got hex %s -> %s, want 2180 -> 2280", ext, d[0].OldHex, d[0].NewHex)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package sff
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// pageBytes returns a copy of the bytes for a page struct pointer.
+func pageBytes(v interface{}) []byte {
+	va := reflect.ValueOf(v)
+	n := int(va.Elem().Type().Size())
+	return append([]byte{}, (*[256]byte)(unsafe.Pointer(va.Pointer()))[:n:n]...)
+}
+
+// Encode returns the eeprom bytes for the module, the inverse of Decode. SFF-8079 is 256 bytes
+// or 512 with diagnostics, SFF-8636 is 256 bytes or 640 with page 03h.
+func Encode(m *Module) ([]byte, error) {
+	switch m.Type {
+	case TypeSff8079:
+		if m.Sff8079 == nil {
+			return nil, ErrUnknownType
+		}
+		b := pageBytes(m.Sff8079)
+		if m.Sff8472 != nil {
+			b = append(b, pageBytes(m.Sff8472)...)
+		}
+		return b, nil
+	case TypeSff8636:
+		if m.Sff8636 == nil {
+			return nil, ErrUnknownType
+		}
+		b := make([]byte, 128)
+		if m.LowerPage != nil {
+			b = pageBytes(m.LowerPage)
+		}
+		if b[0] == 0 {
+			// The lower page identifier isn't in JSON, it's the same as byte 128
+			b[0] = byte(m.Sff8636.Identifier)
+		}
+		b = append(b, pageBytes(m.Sff8636)...)
+		if m.Thresholds != nil {
+			b = append(b, make([]byte, 256)...)
+			b = append(b, pageBytes(m.Thresholds)...)
+		}
+		return b, nil
+	}
+	return nil, ErrUnknownType
+}
@@ -0,0 +1,192 @@
+package sff
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// unitSuffixes are appended to keys in values only JSON, e.g. temperature becomes temperatureC.
+var unitSuffixes = map[string]string{
+	"C":    "C",
+	"V":    "V",
+	"mA":   "MA",
+	"mW":   "MW",
+	"W":    "W",
+	"Mb/s": "Mbps",
+	"%":    "Pct",
+	"m":    "M",
+	"km":   "Km",
+	"nm":   "Nm",
+	"dB":   "Db",
+}
+
+// Encoder writes modules as JSON to an output stream.
+type Encoder struct {
+	w          io.Writer
+	prefix     string
+	indent     string
+	valuesOnly bool
+}
+
+// NewEncoder returns an encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetIndent indents the output like json.MarshalIndent.
+func (e *Encoder) SetIndent(prefix, indent string) {
+	e.prefix, e.indent = prefix, indent
+}
+
+// SetValuesOnly emits plain values with the unit as a key suffix, i.e. "temperatureC": 33.5,
+// instead of objects with value, unit and hex. Values only JSON can't be decoded.
+func (e *Encoder) SetValuesOnly(v bool) {
+	e.valuesOnly = v
+}
+
+// Encode writes the JSON encoding of the module followed by a newline.
+func (e *Encoder) Encode(m *Module) error {
+	v, err := e.generic(m)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(e.w)
+	enc.SetIndent(e.prefix, e.indent)
+	return enc.Encode(v)
+}
+
+// generic returns the module encoding as maps, lists and scalars.
+func (e *Encoder) generic(m *Module) (interface{}, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var r interface{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	if e.valuesOnly {
+		return compact(r), nil
+	}
+	return r, nil
+}
+
+// isField returns true for encoded fields, which have a hex key, or computed values with a
+// value and unit.
+func isField(v interface{}) (map[string]interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if _, ok := m["hex"]; ok {
+		return m, true
+	}
+	_, value := m["value"]
+	_, unit := m["unit"]
+	return m, value && unit
+}
+
+func isFieldList(v interface{}) ([]interface{}, bool) {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 {
+		return nil, false
+	}
+	for _, e := range l {
+		if _, ok := isField(e); !ok {
+			return nil, false
+		}
+	}
+	return l, true
+}
+
+func compact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return compactObject(t)
+	case []interface{}:
+		r := []interface{}{}
+		for _, e := range t {
+			r = append(r, compact(e))
+		}
+		return r
+	}
+	return v
+}
+
+func compactObject(m map[string]interface{}) map[string]interface{} {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	r := map[string]interface{}{}
+	for _, k := range keys {
+		if f, ok := isField(m[k]); ok {
+			compactFields(k, []interface{}{f}, false, m, r)
+		} else if l, ok := isFieldList(m[k]); ok {
+			compactFields(k, l, true, m, r)
+		} else {
+			r[k] = compact(m[k])
+		}
+	}
+	return r
+}
+
+// compactFields adds the value, or values, with a unit suffixed key and any additional keys,
+// such as dbm, suffixed to the key. The unit suffix is skipped if the key already ends with it
+// or the suffixed key is used by another field.
+func compactFields(key string, l []interface{}, list bool, orig map[string]interface{}, r map[string]interface{}) {
+	first := l[0].(map[string]interface{})
+
+	name := key
+	if u, ok := first["unit"].(string); ok {
+		s := unitSuffixes[u]
+		if _, taken := orig[key+s]; s != "" && !strings.HasSuffix(key, s) && !taken {
+			name = key + s
+		}
+	}
+
+	extra := map[string]bool{}
+	for _, e := range l {
+		for k := range e.(map[string]interface{}) {
+			if k != "value" && k != "values" && k != "unit" && k != "hex" {
+				extra[k] = true
+			}
+		}
+	}
+
+	values := func(k string) interface{} {
+		vals := []interface{}{}
+		for _, e := range l {
+			vals = append(vals, e.(map[string]interface{})[k])
+		}
+		if list {
+			return vals
+		}
+		return vals[0]
+	}
+
+	switch {
+	case hasKey(first, "value"):
+		r[name] = values("value")
+	case hasKey(first, "values"):
+		r[name] = values("values")
+	default:
+		r[name] = values("hex")
+	}
+
+	for k := range extra {
+		r[key+strings.ToUpper(k[:1])+k[1:]] = values(k)
+	}
+}
+
+func hasKey(m map[string]interface{}, k string) bool {
+	_, ok := m[k]
+	return ok
+}
@@ -11,14 +11,73 @@ import (
 	"strings"
 
 	"github.com/mickep76/go-sff"
+	_ "github.com/mickep76/go-sff/vendors/arista"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+func decode(b []byte, fromJSON bool) (*sff.Module, error) {
+	if fromJSON {
+		m := &sff.Module{}
+		if err := json.Unmarshal(b, m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	eeprom, err := hex.DecodeString(strings.TrimRight(string(b), "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("decode hex: %v", err)
+	}
+
+	return sff.Decode(eeprom)
+}
+
+func diff(files []string, fromJSON bool, toJSON bool, color bool) {
+	if len(files) != 2 {
+		log.Fatalf("usage: diff <file a> <file b>")
+	}
+
+	mods := []*sff.Module{}
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		m, err := decode(b, fromJSON)
+		if err != nil {
+			log.Fatalf("%s: %v", f, err)
+		}
+		mods = append(mods, m)
+	}
+
+	d := sff.Diff(mods[0], mods[1])
+	switch {
+	case toJSON:
+		b, _ := json.MarshalIndent(d, "", "  ")
+		fmt.Printf("%s\n", string(b))
+	case color:
+		fmt.Print(d.StringCol())
+	default:
+		fmt.Print(d.String())
+	}
+
+	if len(d) > 0 {
+		os.Exit(1)
+	}
+}
+
 func main() {
 	toJSON := flag.Bool("to-json", false, "Output as JSON")
 	fromJSON := flag.Bool("from-json", false, "Input from JSON")
+	color := flag.Bool("color", false, "Output with color")
 	flag.Parse()
 
+	if flag.Arg(0) == "diff" {
+		diff(flag.Args()[1:], *fromJSON, *toJSON, *color)
+		return
+	}
+
 	var b []byte
 	if !terminal.IsTerminal(0) {
 		b, _ = ioutil.ReadAll(os.Stdin)
@@ -27,8 +86,7 @@ func main() {
 	}
 
 	if *fromJSON {
-		m := &sff.Module{}
-		err := json.Unmarshal(b, m)
+		m, err := decode(b, true)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -56,10 +114,13 @@ func main() {
 
 	fmt.Printf("%-51s: %s\n", "Type", m.Type)
 
-	if *toJSON {
+	switch {
+	case *toJSON:
 		b, _ := json.MarshalIndent(m, "", "  ")
 		fmt.Printf("%s\n", string(b))
-	} else {
+	case *color:
+		fmt.Printf("%s\n", m.StringCol())
+	default:
 		fmt.Printf("%s\n", m.String())
 	}
 }
@@ -0,0 +1,183 @@
+// Package flat flattens modules into a stable set of columns for CSV and TSV export.
+package flat
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+// lanes is the number of DOM lanes, SFP modules only use lane 1.
+const lanes = 4
+
+// Columns is the default column order, the same for SFP and QSFP modules.
+var Columns = columns()
+
+func columns() []string {
+	c := []string{
+		"source", "type", "identifier", "connector", "vendor", "vendorName", "vendorOui", "vendorPn",
+		"vendorRev", "vendorSn", "dateCode", "pmd", "compliance", "extCompliance", "brNominalMbps",
+		"wavelengthNm", "lengthSmfM", "lengthOm1M", "lengthOm2M", "lengthOm3M", "lengthOm4M",
+		"lengthCopperM", "temperatureC", "vccV",
+	}
+	for _, f := range []string{"txBiasMA", "txPowerMW", "txPowerDbm", "rxPowerMW", "rxPowerDbm"} {
+		for l := 1; l <= lanes; l++ {
+			c = append(c, fmt.Sprintf("%s%d", f, l))
+		}
+	}
+	return append(c, "alarms", "warnings")
+}
+
+var lengthColumns = map[string]string{
+	common.MediumSmf:    "lengthSmfM",
+	common.MediumOm1:    "lengthOm1M",
+	common.MediumOm2:    "lengthOm2M",
+	common.MediumOm3:    "lengthOm3M",
+	common.MediumOm4:    "lengthOm4M",
+	common.MediumCopper: "lengthCopperM",
+}
+
+// ParseColumns returns the columns for a comma separated list, all columns if it's empty.
+func ParseColumns(s string) ([]string, error) {
+	if s == "" {
+		return Columns, nil
+	}
+
+	valid := map[string]bool{}
+	for _, c := range Columns {
+		valid[c] = true
+	}
+
+	r := []string{}
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if !valid[c] {
+			return nil, fmt.Errorf("unknown column: %s", c)
+		}
+		r = append(r, c)
+	}
+	return r, nil
+}
+
+func float(f float64, prec int) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', prec, 64)
+}
+
+type dom struct {
+	temp     common.Temperature
+	vcc      common.Voltage
+	txBias   []common.Current
+	txPower  []common.Power
+	rxPower  []common.Power
+	alarms   []string
+	warnings []string
+}
+
+func (d *dom) add(r map[string]string) {
+	r["temperatureC"] = float(d.temp.Float64(), 2)
+	r["vccV"] = float(d.vcc.Float64(), 4)
+	for i := range d.txBias {
+		r[fmt.Sprintf("txBiasMA%d", i+1)] = float(d.txBias[i].Float64(), 3)
+		r[fmt.Sprintf("txPowerMW%d", i+1)] = float(d.txPower[i].Float64(), 4)
+		r[fmt.Sprintf("txPowerDbm%d", i+1)] = float(d.txPower[i].Dbm(), 2)
+		r[fmt.Sprintf("rxPowerMW%d", i+1)] = float(d.rxPower[i].Float64(), 4)
+		r[fmt.Sprintf("rxPowerDbm%d", i+1)] = float(d.rxPower[i].Dbm(), 2)
+	}
+	r["alarms"] = strings.Join(d.alarms, "; ")
+	r["warnings"] = strings.Join(d.warnings, "; ")
+}
+
+// Flatten returns the values for all columns, DOM columns are empty if the module has no
+// diagnostics.
+func Flatten(source string, m *sff.Module) map[string]string {
+	r := map[string]string{"source": source, "type": string(m.Type)}
+
+	var reach common.Reaches
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		r["identifier"], r["connector"], r["vendor"], r["vendorName"] = s.Identifier.String(), s.Connector.String(), s.Vendor.String(), s.VendorName()
+		r["vendorOui"], r["vendorPn"], r["vendorRev"], r["vendorSn"] = s.VendorOui.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		r["dateCode"], r["pmd"], r["compliance"], r["extCompliance"] = s.DateCode.String(), s.Pmd(), strings.Join(s.Transceiver.List(), "; "), s.TranscComp.String()
+		r["brNominalMbps"] = strconv.Itoa(int(s.BrNominalMbps()))
+		if !s.Copper() {
+			r["wavelengthNm"] = float(float64(s.LaserWavelength.Nm()), 2)
+		}
+		reach = s.Reach()
+
+		if d := m.Diagnostics(); d != nil {
+			(&dom{d.Temperature, d.Vcc, []common.Current{d.TxBias}, []common.Power{d.TxPower}, []common.Power{d.RxPower}, m.Alarms(), m.Warnings()}).add(r)
+		}
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		r["identifier"], r["connector"], r["vendor"], r["vendorName"] = s.Identifier.String(), s.Connector.String(), s.Vendor.String(), s.VendorName()
+		r["vendorOui"], r["vendorPn"], r["vendorRev"], r["vendorSn"] = s.VendorOui.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		r["dateCode"], r["pmd"], r["compliance"], r["extCompliance"] = s.DateCode.String(), s.Pmd(), strings.Join(s.Transceiver.List(), "; "), s.LinkCodes.String()
+		r["brNominalMbps"] = strconv.Itoa(int(s.BrNominalMbps()))
+		if !s.Copper() {
+			r["wavelengthNm"] = float(s.LaserWavelen.Nm(), 2)
+		}
+		reach = s.Reach()
+
+		if l := m.LowerPage; l != nil {
+			(&dom{l.Temperature, l.Vcc, l.TxBias[:], l.TxPower[:], l.RxPower[:], m.Alarms(), m.Warnings()}).add(r)
+		}
+	}
+
+	for _, v := range reach {
+		if c, ok := lengthColumns[v.Medium]; ok {
+			r[c] = strconv.Itoa(int(v.Meters))
+		}
+	}
+	return r
+}
+
+// Row returns the values for the columns.
+func Row(source string, m *sff.Module, columns []string) []string {
+	f := Flatten(source, m)
+	r := []string{}
+	for _, c := range columns {
+		r = append(r, f[c])
+	}
+	return r
+}
+
+// Writer writes modules as CSV rows, set Comma to '\t' for TSV.
+type Writer struct {
+	Comma   rune
+	Columns []string
+	w       *csv.Writer
+	header  bool
+}
+
+// NewWriter returns a writer with the default columns.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{Comma: ',', Columns: Columns, w: csv.NewWriter(w)}
+}
+
+// Write a row for the module, the header is written before the first row.
+func (w *Writer) Write(source string, m *sff.Module) error {
+	w.w.Comma = w.Comma
+	if !w.header {
+		if err := w.w.Write(w.Columns); err != nil {
+			return err
+		}
+		w.header = true
+	}
+	return w.w.Write(Row(source, m, w.Columns))
+}
+
+// Flush writes buffered rows and returns any error.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
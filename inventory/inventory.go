@@ -0,0 +1,281 @@
+// Package inventory aggregates decoded modules into fleet reports.
+package inventory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mickep76/go-sff"
+)
+
+// Item is the identity of a module.
+type Item struct {
+	Source     string     `json:"source"`
+	Type       string     `json:"type"`
+	FormFactor string     `json:"formFactor"`
+	Vendor     string     `json:"vendor"`
+	VendorPn   string     `json:"vendorPn"`
+	VendorRev  string     `json:"vendorRev"`
+	VendorSn   string     `json:"vendorSn"`
+	Pmd        string     `json:"pmd"`
+	Date       *time.Time `json:"date,omitempty"`
+}
+
+// NewItem returns the identity fields for a module, Date is nil if the date code is invalid.
+func NewItem(source string, m *sff.Module) Item {
+	i := Item{Source: source, Type: string(m.Type)}
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		i.FormFactor, i.Vendor, i.VendorPn, i.VendorRev, i.VendorSn = s.Identifier.String(), s.VendorName(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		i.Pmd = s.Pmd()
+		i.Date = date(s.DateCode.Time())
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		i.FormFactor, i.Vendor, i.VendorPn, i.VendorRev, i.VendorSn = s.Identifier.String(), s.VendorName(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		i.Pmd = s.Pmd()
+		i.Date = date(s.DateCode.Time())
+	}
+	return i
+}
+
+func date(t time.Time, err error) *time.Time {
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// Key is a field to group by.
+type Key string
+
+const (
+	KeyVendor     = Key("vendor")
+	KeyVendorPn   = Key("vendorPn")
+	KeyVendorRev  = Key("vendorRev")
+	KeyFormFactor = Key("formFactor")
+	KeyPmd        = Key("pmd")
+)
+
+// Keys are the supported group keys.
+var Keys = []Key{KeyVendor, KeyVendorPn, KeyVendorRev, KeyFormFactor, KeyPmd}
+
+// ParseKey returns the key for a name, e.g. "vendorPn".
+func ParseKey(name string) (Key, error) {
+	for _, k := range Keys {
+		if strings.EqualFold(string(k), name) {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown group key: %s", name)
+}
+
+func (i Item) value(k Key) string {
+	switch k {
+	case KeyVendor:
+		return i.Vendor
+	case KeyVendorPn:
+		return i.VendorPn
+	case KeyVendorRev:
+		return i.VendorRev
+	case KeyFormFactor:
+		return i.FormFactor
+	case KeyPmd:
+		return i.Pmd
+	}
+	return ""
+}
+
+// Inventory is a set of modules.
+type Inventory struct {
+	Items []Item `json:"items"`
+}
+
+// New returns an empty inventory.
+func New() *Inventory {
+	return &Inventory{Items: []Item{}}
+}
+
+// Add a module to the inventory.
+func (inv *Inventory) Add(source string, m *sff.Module) {
+	inv.Items = append(inv.Items, NewItem(source, m))
+}
+
+// Group is the number of modules with the same values for the group keys.
+type Group struct {
+	Values map[Key]string `json:"values"`
+	Count  int            `json:"count"`
+}
+
+type Groups []Group
+
+// Group counts modules by the keys, sorted by count and then values.
+func (inv *Inventory) Group(keys ...Key) Groups {
+	idx := map[string]int{}
+	g := Groups{}
+	for _, i := range inv.Items {
+		v := map[Key]string{}
+		l := []string{}
+		for _, k := range keys {
+			v[k] = i.value(k)
+			l = append(l, v[k])
+		}
+
+		id := strings.Join(l, "\x00")
+		if n, ok := idx[id]; ok {
+			g[n].Count++
+			continue
+		}
+		idx[id] = len(g)
+		g = append(g, Group{Values: v, Count: 1})
+	}
+
+	sort.SliceStable(g, func(a, b int) bool {
+		if g[a].Count != g[b].Count {
+			return g[a].Count > g[b].Count
+		}
+		for _, k := range keys {
+			if g[a].Values[k] != g[b].Values[k] {
+				return g[a].Values[k] < g[b].Values[k]
+			}
+		}
+		return false
+	})
+	return g
+}
+
+// Age is the number of modules manufactured the given number of whole years ago, Years is
+// negative for date codes in the future.
+type Age struct {
+	Years int `json:"years"`
+	Count int `json:"count"`
+}
+
+// Ages is the age distribution and the number of modules with an invalid date code.
+type Ages struct {
+	Years   []Age `json:"years"`
+	Invalid int   `json:"invalid"`
+}
+
+// Ages returns the age distribution in years relative to now, sorted by age.
+func (inv *Inventory) Ages(now time.Time) *Ages {
+	a := &Ages{Years: []Age{}}
+	c := map[int]int{}
+	for _, i := range inv.Items {
+		if i.Date == nil {
+			a.Invalid++
+			continue
+		}
+		c[int(math.Floor(now.Sub(*i.Date).Hours()/24/365.25))]++
+	}
+
+	for y, n := range c {
+		a.Years = append(a.Years, Age{Years: y, Count: n})
+	}
+	sort.Slice(a.Years, func(i, j int) bool { return a.Years[i].Years < a.Years[j].Years })
+	return a
+}
+
+// Duplicate is a serial number found on more than one module from the same vendor.
+type Duplicate struct {
+	Vendor   string   `json:"vendor"`
+	VendorSn string   `json:"vendorSn"`
+	Sources  []string `json:"sources"`
+}
+
+type Duplicates []Duplicate
+
+// Duplicates returns serial numbers used more than once per vendor, sorted by vendor and serial.
+func (inv *Inventory) Duplicates() Duplicates {
+	idx := map[string]int{}
+	l := Duplicates{}
+	for _, i := range inv.Items {
+		if i.VendorSn == "" {
+			continue
+		}
+
+		id := i.Vendor + "\x00" + i.VendorSn
+		if n, ok := idx[id]; ok {
+			l[n].Sources = append(l[n].Sources, i.Source)
+			continue
+		}
+		idx[id] = len(l)
+		l = append(l, Duplicate{Vendor: i.Vendor, VendorSn: i.VendorSn, Sources: []string{i.Source}})
+	}
+
+	d := Duplicates{}
+	for _, v := range l {
+		if len(v.Sources) > 1 {
+			d = append(d, v)
+		}
+	}
+	sort.Slice(d, func(a, b int) bool {
+		if d[a].Vendor != d[b].Vendor {
+			return d[a].Vendor < d[b].Vendor
+		}
+		return d[a].VendorSn < d[b].VendorSn
+	})
+	return d
+}
+
+// Revision is the revisions and their counts for a vendor part number.
+type Revision struct {
+	Vendor    string         `json:"vendor"`
+	VendorPn  string         `json:"vendorPn"`
+	Revisions map[string]int `json:"revisions"`
+}
+
+type Revisions []Revision
+
+// Revisions returns the revision spread per vendor part number, sorted by vendor and PN.
+func (inv *Inventory) Revisions() Revisions {
+	idx := map[string]int{}
+	r := Revisions{}
+	for _, i := range inv.Items {
+		id := i.Vendor + "\x00" + i.VendorPn
+		n, ok := idx[id]
+		if !ok {
+			n = len(r)
+			idx[id] = n
+			r = append(r, Revision{Vendor: i.Vendor, VendorPn: i.VendorPn, Revisions: map[string]int{}})
+		}
+		r[n].Revisions[i.VendorRev]++
+	}
+
+	sort.Slice(r, func(a, b int) bool {
+		if r[a].Vendor != r[b].Vendor {
+			return r[a].Vendor < r[b].Vendor
+		}
+		return r[a].VendorPn < r[b].VendorPn
+	})
+	return r
+}
+
+// Report is the aggregated inventory.
+type Report struct {
+	Total      int        `json:"total"`
+	Keys       []Key      `json:"keys"`
+	Groups     Groups     `json:"groups"`
+	Ages       *Ages      `json:"ages"`
+	Duplicates Duplicates `json:"duplicates"`
+	Revisions  Revisions  `json:"revisions"`
+}
+
+// Report groups by keys, vendor and PN if no keys are given, and computes ages relative to now.
+func (inv *Inventory) Report(now time.Time, keys ...Key) *Report {
+	if len(keys) == 0 {
+		keys = []Key{KeyVendor, KeyVendorPn}
+	}
+
+	return &Report{
+		Total:      len(inv.Items),
+		Keys:       keys,
+		Groups:     inv.Group(keys...),
+		Ages:       inv.Ages(now),
+		Duplicates: inv.Duplicates(),
+		Revisions:  inv.Revisions(),
+	}
+}
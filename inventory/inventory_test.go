@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mickep76/go-sff"
+)
+
+// sfp returns an SFP module, an empty date is an invalid date code.
+func sfp(t *testing.T, vendor, pn, rev, sn, date string) *sff.Module {
+	t.Helper()
+
+	pad := func(s string, n int) string {
+		return s + strings.Repeat(" ", n-len(s))
+	}
+
+	b := make([]byte, 256)
+	b[0], b[1] = 0x03, 0x04
+	copy(b[20:36], pad(vendor, 16))
+	copy(b[40:56], pad(pn, 16))
+	copy(b[56:60], pad(rev, 4))
+	copy(b[68:84], pad(sn, 16))
+	copy(b[84:92], pad(date, 8))
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// inventory returns modules where the vendor names are normalized, e.g. FINISAR CORP. to Finisar.
+func inventory(t *testing.T) *Inventory {
+	t.Helper()
+
+	inv := New()
+	inv.Add("et1", sfp(t, "FINISAR CORP.", "FTLX8571D3BCL", "A", "ALA0001", "190101"))
+	inv.Add("et2", sfp(t, "FINISAR CORP.", "FTLX8571D3BCL", "B", "ALA0002", "190601"))
+	inv.Add("et3", sfp(t, "FINISAR CORP.", "FTLX8571D3BCL", "A", "ALA0001", "230101"))
+	inv.Add("et4", sfp(t, "FINISAR CORP.", "FTLX1471D3BCL", "A", "ALA0003", ""))
+	inv.Add("et5", sfp(t, "Arista Networks", "SFP-10G-SR", "01", "ALA0001", "250601"))
+	inv.Add("et6", sfp(t, "Arista Networks", "SFP-10G-SR", "01", "", "19AB01"))
+	return inv
+}
+
+func TestGroup(t *testing.T) {
+	inv := inventory(t)
+
+	tests := []struct {
+		keys []Key
+		want Groups
+	}{
+		{[]Key{KeyVendor}, Groups{
+			{Values: map[Key]string{KeyVendor: "Finisar"}, Count: 4},
+			{Values: map[Key]string{KeyVendor: "Arista"}, Count: 2},
+		}},
+		{[]Key{KeyVendor, KeyVendorPn}, Groups{
+			{Values: map[Key]string{KeyVendor: "Finisar", KeyVendorPn: "FTLX8571D3BCL"}, Count: 3},
+			{Values: map[Key]string{KeyVendor: "Arista", KeyVendorPn: "SFP-10G-SR"}, Count: 2},
+			{Values: map[Key]string{KeyVendor: "Finisar", KeyVendorPn: "FTLX1471D3BCL"}, Count: 1},
+		}},
+		{[]Key{KeyVendorRev}, Groups{
+			{Values: map[Key]string{KeyVendorRev: "A"}, Count: 3},
+			{Values: map[Key]string{KeyVendorRev: "01"}, Count: 2},
+			{Values: map[Key]string{KeyVendorRev: "B"}, Count: 1},
+		}},
+	}
+	for _, tt := range tests {
+		if got := inv.Group(tt.keys...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: got %+v, want %+v", tt.keys, got, tt.want)
+		}
+	}
+}
+
+func TestAges(t *testing.T) {
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	want := &Ages{Years: []Age{{-1, 1}, {1, 1}, {5, 2}}, Invalid: 2}
+	if got := inventory(t).Ages(now); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if got := New().Ages(now); len(got.Years) != 0 || got.Invalid != 0 {
+		t.Errorf("got %+v for no modules, want none", got)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	want := Duplicates{{Vendor: "Finisar", VendorSn: "ALA0001", Sources: []string{"et1", "et3"}}}
+	if got := inventory(t).Duplicates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	want := Revisions{
+		{Vendor: "Arista", VendorPn: "SFP-10G-SR", Revisions: map[string]int{"01": 2}},
+		{Vendor: "Finisar", VendorPn: "FTLX1471D3BCL", Revisions: map[string]int{"A": 1}},
+		{Vendor: "Finisar", VendorPn: "FTLX8571D3BCL", Revisions: map[string]int{"A": 2, "B": 1}},
+	}
+	if got := inventory(t).Revisions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
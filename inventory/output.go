@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	cyan  = "\x1b[36m"
+	clear = "\x1b[0m"
+)
+
+// table is a header and rows used for text and CSV output.
+type table struct {
+	title  string
+	header []string
+	rows   [][]string
+}
+
+func (g Groups) table(keys []Key) table {
+	t := table{title: "Groups"}
+	for _, k := range keys {
+		t.header = append(t.header, string(k))
+	}
+	t.header = append(t.header, "count")
+	for _, v := range g {
+		r := []string{}
+		for _, k := range keys {
+			r = append(r, v.Values[k])
+		}
+		t.rows = append(t.rows, append(r, strconv.Itoa(v.Count)))
+	}
+	return t
+}
+
+func (a *Ages) table() table {
+	t := table{title: "Age", header: []string{"years", "count"}}
+	for _, v := range a.Years {
+		t.rows = append(t.rows, []string{strconv.Itoa(v.Years), strconv.Itoa(v.Count)})
+	}
+	if a.Invalid > 0 {
+		t.rows = append(t.rows, []string{"invalid", strconv.Itoa(a.Invalid)})
+	}
+	return t
+}
+
+func (d Duplicates) table() table {
+	t := table{title: "Duplicate Serials", header: []string{"vendor", "vendorSn", "count", "sources"}}
+	for _, v := range d {
+		t.rows = append(t.rows, []string{v.Vendor, v.VendorSn, strconv.Itoa(len(v.Sources)), strings.Join(v.Sources, " ")})
+	}
+	return t
+}
+
+func (r Revisions) table() table {
+	t := table{title: "Revisions", header: []string{"vendor", "vendorPn", "revisions"}}
+	for _, v := range r {
+		revs := []string{}
+		for k := range v.Revisions {
+			revs = append(revs, k)
+		}
+		sort.Strings(revs)
+
+		l := []string{}
+		for _, k := range revs {
+			l = append(l, fmt.Sprintf("%s (%d)", k, v.Revisions[k]))
+		}
+		t.rows = append(t.rows, []string{v.Vendor, v.VendorPn, strings.Join(l, " ")})
+	}
+	return t
+}
+
+func (inv *Inventory) table() table {
+	t := table{title: "Items", header: []string{"source", "type", "formFactor", "vendor", "vendorPn", "vendorRev", "vendorSn", "pmd", "date"}}
+	for _, i := range inv.Items {
+		d := ""
+		if i.Date != nil {
+			d = i.Date.Format("2006-01-02")
+		}
+		t.rows = append(t.rows, []string{i.Source, i.Type, i.FormFactor, i.Vendor, i.VendorPn, i.VendorRev, i.VendorSn, i.Pmd, d})
+	}
+	return t
+}
+
+func (t table) text(color bool) string {
+	b := &bytes.Buffer{}
+	w := tabwriter.NewWriter(b, 0, 8, 2, ' ', 0)
+	h := strings.ToUpper(strings.Join(t.header, "\t"))
+	if color {
+		h = cyan + h + clear
+	}
+	fmt.Fprintln(w, h)
+	for _, r := range t.rows {
+		fmt.Fprintln(w, strings.Join(r, "\t"))
+	}
+	w.Flush()
+	return b.String()
+}
+
+func (t table) writeCSV(w *csv.Writer) error {
+	if err := w.Write(t.header); err != nil {
+		return err
+	}
+	return w.WriteAll(t.rows)
+}
+
+func (r *Report) tables() []table {
+	return []table{r.Groups.table(r.Keys), r.Ages.table(), r.Duplicates.table(), r.Revisions.table()}
+}
+
+func (r *Report) text(color bool) string {
+	str := fmt.Sprintf("%-50s : %d\n", "Total", r.Total)
+	if color {
+		str = fmt.Sprintf("%s%-50s%s : %d\n", cyan, "Total", clear, r.Total)
+	}
+	for _, t := range r.tables() {
+		if color {
+			str += fmt.Sprintf("\n%s%s%s\n", cyan, t.title, clear)
+		} else {
+			str += fmt.Sprintf("\n%s\n", t.title)
+		}
+		str += t.text(color)
+	}
+	return str
+}
+
+func (r *Report) String() string {
+	return r.text(false)
+}
+
+func (r *Report) StringCol() string {
+	return r.text(true)
+}
+
+// WriteCSV writes the groups, age, duplicate serials and revisions tables separated by an
+// empty line, comma is the field delimiter, e.g. '\t' for TSV.
+func (r *Report) WriteCSV(w io.Writer, comma rune) error {
+	c := csv.NewWriter(w)
+	c.Comma = comma
+	for n, t := range r.tables() {
+		if n > 0 {
+			c.Flush()
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := t.writeCSV(c); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func (inv *Inventory) String() string {
+	return inv.table().text(false)
+}
+
+func (inv *Inventory) StringCol() string {
+	return inv.table().text(true)
+}
+
+// WriteCSV writes one row per module.
+func (inv *Inventory) WriteCSV(w io.Writer, comma rune) error {
+	c := csv.NewWriter(w)
+	c.Comma = comma
+	if err := inv.table().writeCSV(c); err != nil {
+		return err
+	}
+	c.Flush()
+	return c.Error()
+}
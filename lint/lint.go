@@ -0,0 +1,171 @@
+// Package lint runs a configurable rule set over decoded modules.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+const (
+	red    = "\x1b[31m"
+	yellow = "\x1b[33m"
+	blue   = "\x1b[34m"
+	cyan   = "\x1b[36m"
+	clear  = "\x1b[0m"
+)
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+var severityColors = map[Severity]string{
+	SeverityInfo:    blue,
+	SeverityWarning: yellow,
+	SeverityError:   red,
+}
+
+func (s Severity) String() string {
+	n, ok := severityNames[s]
+	if !ok {
+		return "unknown"
+	}
+	return n
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *Severity) UnmarshalJSON(in []byte) error {
+	var n string
+	if err := json.Unmarshal(in, &n); err != nil {
+		return err
+	}
+
+	for k, v := range severityNames {
+		if v == n {
+			*s = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity: %s", n)
+}
+
+// Rule checks a module and returns the issues found.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+	Check       func(m *sff.Module) []common.Issue
+}
+
+// Finding is an issue reported by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	common.Issue
+}
+
+func (f Finding) key() string {
+	if f.Field == "" {
+		return fmt.Sprintf("%s %s", f.Severity, f.Rule)
+	}
+	return fmt.Sprintf("%s %s %s [%d]", f.Severity, f.Rule, f.Field, f.Offset)
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%-50s : %s", f.key(), f.Message)
+}
+
+func (f Finding) StringCol() string {
+	return fmt.Sprintf("%s%-50s%s : %s%s%s", cyan, f.key(), clear, severityColors[f.Severity], f.Message, clear)
+}
+
+type Findings []Finding
+
+func (f Findings) String() string {
+	str := ""
+	for _, v := range f {
+		str += v.String() + "\n"
+	}
+	return str
+}
+
+func (f Findings) StringCol() string {
+	str := ""
+	for _, v := range f {
+		str += v.StringCol() + "\n"
+	}
+	return str
+}
+
+// Max returns the highest severity, SeverityInfo if there are no findings.
+func (f Findings) Max() Severity {
+	s := SeverityInfo
+	for _, v := range f {
+		if v.Severity > s {
+			s = v.Severity
+		}
+	}
+	return s
+}
+
+// Config disables rules and overrides their severity by rule ID.
+type Config struct {
+	Disable  []string            `json:"disable,omitempty"`
+	Severity map[string]Severity `json:"severity,omitempty"`
+}
+
+// Linter runs a set of rules.
+type Linter struct {
+	Rules []Rule
+}
+
+// New returns a linter with the default rules.
+func New() *Linter {
+	return &Linter{Rules: Rules()}
+}
+
+// Configure disables rules and overrides severities.
+func (l *Linter) Configure(c Config) {
+	disabled := map[string]bool{}
+	for _, id := range c.Disable {
+		disabled[id] = true
+	}
+
+	rules := []Rule{}
+	for _, r := range l.Rules {
+		if disabled[r.ID] {
+			continue
+		}
+		if s, ok := c.Severity[r.ID]; ok {
+			r.Severity = s
+		}
+		rules = append(rules, r)
+	}
+	l.Rules = rules
+}
+
+// Lint runs all rules over the module.
+func (l *Linter) Lint(m *sff.Module) Findings {
+	f := Findings{}
+	for _, r := range l.Rules {
+		for _, i := range r.Check(m) {
+			f = append(f, Finding{Rule: r.ID, Severity: r.Severity, Issue: i})
+		}
+	}
+	return f
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+// sfp returns a 10GBASE-SR SFP+ with valid checksums after applying f to the bytes.
+func sfp(t *testing.T, f func(b []byte)) *sff.Module {
+	t.Helper()
+
+	b := make([]byte, 256)
+	b[0], b[1], b[2] = 0x03, 0x04, byte(common.ConnectorLc)
+	b[3] = 0x10               // 10GBASE-SR
+	b[12] = 103               // 10.3 Gb/s
+	b[19] = 30                // 300 m OM3
+	b[60], b[61] = 0x03, 0x52 // 850 nm
+	b[94] = 0x08
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[37:40], []byte{0x00, 0x90, 0x65})
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[56:60], "A   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	if f != nil {
+		f(b)
+	}
+	b[63] = byte(common.CalculateChecksum(b[0:63]))
+	b[95] = byte(common.CalculateChecksum(b[64:95]))
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func rules(f Findings) string {
+	l := []string{}
+	for _, v := range f {
+		l = append(l, v.Rule)
+	}
+	return strings.Join(l, ",")
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		eeprom func(b []byte)
+		module func(m *sff.Module)
+		want   string
+	}{
+		{"ok", nil, nil, ""},
+		{"cc-base", nil, func(m *sff.Module) { m.Sff8079.CcBase++ }, "cc-base"},
+		{"cc-ext", nil, func(m *sff.Module) { m.Sff8079.CcExt++ }, "cc-ext"},
+		{"ascii", func(b []byte) { b[45] = 0x01 }, nil, "ascii"},
+		{"ascii NUL padded", func(b []byte) { copy(b[57:60], []byte{0, 0, 0}) }, nil, "ascii"},
+		{"ascii all NUL", func(b []byte) { copy(b[56:60], []byte{0, 0, 0, 0}) }, nil, "ascii"},
+		{"date-code", func(b []byte) { copy(b[84:], "19AB01") }, nil, "date-code"},
+		{"oui-vendor", func(b []byte) { copy(b[37:40], []byte{0x00, 0x1c, 0x73}) }, nil, "oui-vendor"},
+		{"serial-zero", func(b []byte) { copy(b[68:84], "0000            ") }, nil, "serial-zero"},
+		{"identifier-reserved", nil, func(m *sff.Module) {
+			m.Sff8079.Identifier = 0x80
+			m.Sff8079.CcBase = m.Sff8079.ChecksumBase()
+		}, "identifier-reserved"},
+		{"copper connector", func(b []byte) { b[2] = byte(common.ConnectorCopperPtail) }, nil, "connector-compliance"},
+		{"optical connector", func(b []byte) { b[3], b[8], b[18] = 0, 0x04, 3 }, nil, "connector-compliance"},
+		{"copper with fiber length", func(b []byte) { b[2], b[3], b[8], b[15] = byte(common.ConnectorCopperPtail), 0, 0x04, 10 }, nil, "length-media"},
+		{"optical without length", func(b []byte) { b[19] = 0 }, nil, "length-media"},
+		{"smf length", func(b []byte) { b[14], b[15] = 10, 20 }, nil, "length-media"},
+		{"bit rate", func(b []byte) { b[12] = 13 }, nil, "br-compliance"},
+		{"dual-rate 10G", func(b []byte) { b[6] = 0x01 }, nil, ""},
+		{"dual-rate 1G", func(b []byte) { b[6], b[12] = 0x01, 13 }, nil, ""},
+		{"dual-rate 25G", func(b []byte) { b[6], b[12] = 0x01, 0xFF; b[66] = 103 }, nil, "br-compliance"},
+		{"ext compliance 25G", func(b []byte) { b[3], b[36] = 0, byte(common.ExtCompliance100gSr4) }, nil, "br-compliance"},
+		{"ext compliance 25G rate", func(b []byte) { b[3], b[12], b[36], b[66] = 0, 0xFF, byte(common.ExtCompliance100gSr4), 103 }, nil, ""},
+	}
+	for _, tt := range tests {
+		m := sfp(t, tt.eeprom)
+		if tt.module != nil {
+			tt.module(m)
+		}
+		if got := rules(New().Lint(m)); got != tt.want {
+			t.Errorf("%s: got rules %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	m := sfp(t, func(b []byte) { copy(b[68:84], "0000            ") })
+	m.Sff8079.CcBase++
+
+	l := New()
+	l.Configure(Config{Disable: []string{"serial-zero"}, Severity: map[string]Severity{"cc-base": SeverityWarning}})
+
+	f := l.Lint(m)
+	if got := rules(f); got != "cc-base" {
+		t.Fatalf("got rules %q, want %q", got, "cc-base")
+	}
+	if f[0].Severity != SeverityWarning {
+		t.Errorf("got severity %s, want %s", f[0].Severity, SeverityWarning)
+	}
+	if f.Max() != SeverityWarning {
+		t.Errorf("got max severity %s, want %s", f.Max(), SeverityWarning)
+	}
+
+	if f := New().Lint(m); f.Max() != SeverityError {
+		t.Errorf("got max severity %s without config, want %s", f.Max(), SeverityError)
+	}
+}
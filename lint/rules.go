@@ -0,0 +1,364 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/sff8079"
+	"github.com/mickep76/go-sff/sff8636"
+)
+
+// Rules returns the default rule set.
+func Rules() []Rule {
+	return []Rule{
+		{"cc-base", SeverityError, "CC_BASE checksum mismatch", checkCcBase},
+		{"cc-ext", SeverityError, "CC_EXT checksum mismatch", checkCcExt},
+		{"ascii", SeverityWarning, "String fields that aren't space padded printable ASCII", checkASCII},
+		{"date-code", SeverityWarning, "Invalid date code", checkDateCode},
+		{"oui-vendor", SeverityWarning, "OUI registered to a different vendor than the vendor name", checkOuiVendor},
+		{"serial-zero", SeverityError, "Empty or zero serial number", checkSerial},
+		{"identifier-reserved", SeverityWarning, "Reserved or vendor specific identifier", checkIdentifier},
+		{"connector-compliance", SeverityWarning, "Compliance codes contradicting the connector type", checkConnector},
+		{"length-media", SeverityWarning, "Lengths inconsistent with the media type", checkLength},
+		{"br-compliance", SeverityWarning, "Nominal bit rate inconsistent with the compliance codes", checkBitRate},
+	}
+}
+
+// module holds the fields shared by SFF-8079 and SFF-8636 with byte offsets.
+type module struct {
+	identifier common.Identifier
+	connector  common.Connector
+	vendor     common.String16
+	vendorOui  common.VendorOUI
+	vendorSn   common.String16
+	dateCode   common.DateCode
+	extComp    common.ExtCompliance
+	ccBase     common.Checksum
+	ccExt      common.Checksum
+	calcBase   common.Checksum
+	calcExt    common.Checksum
+	copper     bool
+	br         uint
+	base       int // Offset of the identifier byte
+	offsets    map[string]int
+}
+
+var offsets8079 = map[string]int{"Vendor": 20, "VendorOui": 37, "VendorSn": 68, "DateCode": 84, "CcBase": 63, "CcExt": 95, "TranscComp": 36, "BrNominal": 12, "Length": 14}
+var offsets8636 = map[string]int{"Vendor": 148, "VendorOui": 165, "VendorSn": 196, "DateCode": 212, "CcBase": 191, "CcExt": 223, "LinkCodes": 192, "BrNominal": 140, "Length": 142}
+
+func fields(m *sff.Module) *module {
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		return &module{s.Identifier, s.Connector, s.Vendor, s.VendorOui, s.VendorSn, s.DateCode, s.TranscComp,
+			s.CcBase, s.CcExt, s.ChecksumBase(), s.ChecksumExt(), s.Copper(), s.BrNominalMbps(), 0, offsets8079}
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		return &module{s.Identifier, s.Connector, s.Vendor, s.VendorOui, s.VendorSn, s.DateCode, s.LinkCodes,
+			s.CcBase, s.CcExt, s.ChecksumBase(), s.ChecksumExt(), s.Copper(), s.BrNominalMbps(), 128, offsets8636}
+	}
+	return nil
+}
+
+func issue(f *module, field string, format string, args ...interface{}) []common.Issue {
+	return []common.Issue{{Field: field, Offset: f.offsets[field], Message: fmt.Sprintf(format, args...)}}
+}
+
+func checkCcBase(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil || f.ccBase == f.calcBase {
+		return nil
+	}
+	return issue(f, "CcBase", "checksum is %s, calculated %s", f.ccBase, f.calcBase)
+}
+
+func checkCcExt(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil || f.ccExt == f.calcExt {
+		return nil
+	}
+	return issue(f, "CcExt", "checksum is %s, calculated %s", f.ccExt, f.calcExt)
+}
+
+func checkASCII(m *sff.Module) []common.Issue {
+	return m.Lint()
+}
+
+func checkDateCode(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+	if _, err := f.dateCode.Time(); err != nil {
+		return issue(f, "DateCode", "%v", err)
+	}
+	return nil
+}
+
+func checkOuiVendor(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+
+	o, ok := f.vendorOui.Organization()
+	if !ok {
+		return nil
+	}
+
+	if v := common.NormalizeVendor(f.vendor.String()); v != "" && v != common.NormalizeVendor(o) {
+		return issue(f, "VendorOui", "OUI %s is registered to %s, vendor is %s", f.vendorOui, o, f.vendor)
+	}
+	return nil
+}
+
+func checkSerial(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+
+	if strings.Trim(f.vendorSn.String(), "0") == "" {
+		return issue(f, "VendorSn", "serial number is empty or zero: %q", f.vendorSn.String())
+	}
+	return nil
+}
+
+func checkIdentifier(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+
+	if f.identifier.VendorSpecific() || f.identifier.String() == "Reserved or unknown" {
+		return []common.Issue{{Field: "Identifier", Offset: f.base, Message: fmt.Sprintf("identifier 0x%02x is %s", byte(f.identifier), strings.ToLower(f.identifier.String()))}}
+	}
+	return nil
+}
+
+// compliance is a transceiver compliance code with the expected nominal bit rate range in Mb/s,
+// zero if unchecked.
+type compliance struct {
+	code     uint64
+	name     string
+	copper   bool
+	min, max uint
+}
+
+var compliance8079 = []compliance{
+	{sff8079.Ether10gBaseSr, "10GBASE-SR", false, 9900, 11500},
+	{sff8079.Ether10gBaeLr, "10GBASE-LR", false, 9900, 11500},
+	{sff8079.Ether10gBaseLrm, "10GBASE-LRM", false, 9900, 11500},
+	{sff8079.Ether10gBaseEr, "10GBASE-ER", false, 9900, 11500},
+	{sff8079.Ether1000BaseSx, "1000BASE-SX", false, 1000, 1400},
+	{sff8079.Ether1000BaseLx, "1000BASE-LX", false, 1000, 1400},
+	{sff8079.Ether100BaseFx, "100BASE-FX", false, 100, 200},
+	{sff8079.Ether100BaseLx, "100BASE-LX10", false, 100, 200},
+	{sff8079.Ether1000BaseCx, "1000BASE-CX", true, 1000, 1400},
+	{sff8079.Ether1000BaseT, "1000BASE-T", true, 1000, 1400},
+	{sff8079.PassiveCable, "Passive cable", true, 0, 0},
+	{sff8079.ActiveCable, "Active cable", true, 0, 0},
+}
+
+var compliance8636 = []compliance{
+	{sff8636.Ethernet10gSr, "10GBASE-SR", false, 9900, 11500},
+	{sff8636.Ethernet10gLr, "10GBASE-LR", false, 9900, 11500},
+	{sff8636.Ethernet10gLrm, "10GBASE-LRM", false, 9900, 11500},
+	{sff8636.Ethernet40gSr4, "40GBASE-SR4", false, 9900, 11500},
+	{sff8636.Ethernet40gLr4, "40GBASE-LR4", false, 9900, 11500},
+	{sff8636.Ethernet40gCr4, "40GBASE-CR4", true, 9900, 11500},
+}
+
+// transceiver returns the transceiver compliance bits and the codes they're matched against.
+func transceiver(m *sff.Module) (uint64, []compliance) {
+	switch m.Type {
+	case sff.TypeSff8079:
+		return m.Sff8079.Transceiver.Uint64(), compliance8079
+	case sff.TypeSff8636:
+		return m.Sff8636.Transceiver.Uint64(), compliance8636
+	}
+	return 0, nil
+}
+
+// complianceCodes returns the copper and optical compliance code names.
+func complianceCodes(m *sff.Module, f *module) (copper []string, optical []string) {
+	t, l := transceiver(m)
+
+	for _, c := range l {
+		switch {
+		case t&c.code == 0:
+		case c.copper:
+			copper = append(copper, c.name)
+		default:
+			optical = append(optical, c.name)
+		}
+	}
+
+	switch {
+	case f.extComp.Copper():
+		copper = append(copper, f.extComp.String())
+	case f.extComp.Optical():
+		optical = append(optical, f.extComp.String())
+	}
+	return copper, optical
+}
+
+func checkConnector(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+
+	copper, optical := complianceCodes(m, f)
+	switch {
+	case f.connector.Optical() && len(copper) > 0:
+		return []common.Issue{{Field: "Connector", Offset: f.base + 2, Message: fmt.Sprintf("optical connector %s with copper compliance %s", f.connector, strings.Join(copper, ", "))}}
+	case f.connector.Copper() && len(optical) > 0:
+		return []common.Issue{{Field: "Connector", Offset: f.base + 2, Message: fmt.Sprintf("copper connector %s with optical compliance %s", f.connector, strings.Join(optical, ", "))}}
+	}
+	return nil
+}
+
+func checkLength(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil {
+		return nil
+	}
+
+	var r common.Reaches
+	switch m.Type {
+	case sff.TypeSff8079:
+		r = m.Sff8079.Reach()
+		s := m.Sff8079
+		if s.LengthSmfKm != 0 && s.LengthSmfM != 0 && s.LengthSmfM != 0xFF {
+			if d := int(s.LengthSmfKm.Meters()) - int(s.LengthSmfM.Meters()); d > 1000 || d < -1000 {
+				return issue(f, "Length", "SMF length %s [14] doesn't match %s [15]", s.LengthSmfKm, s.LengthSmfM)
+			}
+		}
+	case sff.TypeSff8636:
+		r = m.Sff8636.Reach()
+	}
+
+	media := []string{}
+	for _, v := range r {
+		if (f.copper && v.Medium != common.MediumCopper) || (!f.copper && v.Medium == common.MediumCopper) {
+			media = append(media, v.String())
+		}
+	}
+
+	switch {
+	case len(media) > 0 && f.copper:
+		return issue(f, "Length", "copper cable with fiber lengths: %s", strings.Join(media, ", "))
+	case len(media) > 0:
+		return issue(f, "Length", "optical module with copper length: %s", strings.Join(media, ", "))
+	case len(r) == 0 && f.connector.Optical():
+		return issue(f, "Length", "optical module without any supported length")
+	}
+	return nil
+}
+
+// extComplianceRates is the nominal bit rate range in Mb/s for extended compliance codes, zero
+// max for no upper limit. 25G and faster lanes only have a lower limit.
+var extComplianceRates = map[common.ExtCompliance][2]uint{
+	common.ExtCompliance40gEr4:         {9900, 11500},
+	common.ExtCompliance4x10gSr:        {9900, 11500},
+	common.ExtCompliance40gPsm4:        {9900, 11500},
+	common.ExtComplianceG959P1i12d1:    {9900, 11500},
+	common.ExtComplianceG959P1s12d2:    {9900, 11500},
+	common.ExtComplianceG959P1l12d2:    {9900, 11500},
+	common.ExtCompliance10gTSfi:        {9900, 11500},
+	common.ExtCompliance10gTSr:         {9900, 11500},
+	common.ExtCompliance40gSwdm4:       {9900, 11500},
+	common.ExtCompliance10gBr:          {9900, 11500},
+	common.ExtCompliance100gAoc5e5:     {24000, 0},
+	common.ExtCompliance100gSr4:        {24000, 0},
+	common.ExtCompliance100gLr4:        {24000, 0},
+	common.ExtCompliance100gEr4:        {24000, 0},
+	common.ExtCompliance100gSr10:       {24000, 0},
+	common.ExtCompliance100gCwdm4:      {24000, 0},
+	common.ExtCompliance100gPsm4:       {24000, 0},
+	common.ExtCompliance100gAcc5e5:     {24000, 0},
+	common.ExtCompliance100gCwdm4NoFec: {24000, 0},
+	common.ExtCompliance100gCr4:        {24000, 0},
+	common.ExtCompliance25gCrS:         {24000, 0},
+	common.ExtCompliance25gCrN:         {24000, 0},
+	common.ExtCompliance100gClr4:       {24000, 0},
+	common.ExtCompliance100gAoc1e12:    {24000, 0},
+	common.ExtCompliance100gAcc1e12:    {24000, 0},
+	common.ExtCompliance100gDwdm2:      {24000, 0},
+	common.ExtCompliance100gWdm:        {24000, 0},
+	common.ExtCompliance100gSwdm4:      {24000, 0},
+	common.ExtCompliance100gPam4Bidi:   {24000, 0},
+	common.ExtCompliance4wdm10:         {24000, 0},
+	common.ExtCompliance4wdm20:         {24000, 0},
+	common.ExtCompliance4wdm40:         {24000, 0},
+	common.ExtCompliance100gDr:         {24000, 0},
+	common.ExtCompliance100gFr:         {24000, 0},
+	common.ExtCompliance100gLr:         {24000, 0},
+	common.ExtCompliance100gSr1Caui4:   {24000, 0},
+	common.ExtCompliance400gSr4:        {24000, 0},
+	common.ExtCompliance100gFr1:        {24000, 0},
+	common.ExtCompliance100gLr1:        {24000, 0},
+	common.ExtCompliance100gLr120Caui4: {24000, 0},
+	common.ExtCompliance100gEr130Caui4: {24000, 0},
+	common.ExtCompliance100gEr140Caui4: {24000, 0},
+	common.ExtCompliance100gLr120:      {24000, 0},
+	common.ExtComplianceAcc50gAui1e6:   {24000, 0},
+	common.ExtComplianceAoc50gAui1e6:   {24000, 0},
+	common.ExtComplianceAcc50gAui2e4:   {24000, 0},
+	common.ExtComplianceAoc50gAui2e4:   {24000, 0},
+	common.ExtCompliance100gEr130:      {24000, 0},
+	common.ExtCompliance100gEr140:      {24000, 0},
+	common.ExtCompliance400gVr4:        {24000, 0},
+	common.ExtCompliance25gBr:          {24000, 0},
+	common.ExtCompliance50gBr:          {24000, 0},
+	common.ExtCompliance100gVr1Caui4:   {24000, 0},
+	common.ExtCompliance400gCr4:        {24000, 0},
+	common.ExtCompliance50gCr:          {24000, 0},
+	common.ExtCompliance50gSr:          {24000, 0},
+	common.ExtCompliance50gFr:          {24000, 0},
+	common.ExtCompliance200gFr4:        {24000, 0},
+	common.ExtCompliance200gPsm4:       {24000, 0},
+	common.ExtCompliance50gLr:          {24000, 0},
+	common.ExtCompliance200gLr4:        {24000, 0},
+	common.ExtCompliance400gDr4:        {24000, 0},
+	common.ExtCompliance400gFr4:        {24000, 0},
+	common.ExtCompliance400gLr46:       {24000, 0},
+	common.ExtCompliance50gEr:          {24000, 0},
+	common.ExtCompliance400gLr410:      {24000, 0},
+	common.ExtCompliance400gZr:         {24000, 0},
+}
+
+func checkBitRate(m *sff.Module) []common.Issue {
+	f := fields(m)
+	if f == nil || f.br == 0 {
+		return nil
+	}
+
+	// Multi-rate modules, such as 10GBASE-SR and 1000BASE-SX, match if the bit rate is in the
+	// range of any of the codes.
+	t, l := transceiver(m)
+	ranges := [][2]uint{}
+	for _, c := range l {
+		if t&c.code != 0 && c.min > 0 {
+			ranges = append(ranges, [2]uint{c.min, c.max})
+		}
+	}
+	if r, ok := extComplianceRates[f.extComp]; ok {
+		ranges = append(ranges, r)
+	}
+
+	for _, r := range ranges {
+		if f.br >= r[0] && (r[1] == 0 || f.br <= r[1]) {
+			return nil
+		}
+	}
+
+	if len(ranges) > 0 {
+		copper, optical := complianceCodes(m, f)
+		return issue(f, "BrNominal", "nominal bit rate %d Mb/s doesn't match compliance %s", f.br, strings.Join(append(copper, optical...), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,441 @@
+// Package openconfig renders modules as openconfig-platform-transceiver state in RFC 7951 JSON.
+package openconfig
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+)
+
+const (
+	transportTypes = "openconfig-transport-types:"
+	alarmTypes     = "openconfig-alarm-types:"
+)
+
+// Identities for unknown form factors and PMDs.
+const (
+	FormFactorOther = transportTypes + "OTHER"
+	PmdUndefined    = transportTypes + "ETH_UNDEFINED"
+)
+
+// noPowerDbm is reported instead of -Inf for no power.
+const noPowerDbm = -40
+
+var formFactors = map[common.Identifier]string{
+	common.IdentifierSoldered:  "NON_PLUGGABLE",
+	common.IdentifierSfp:       "SFP",
+	common.IdentifierXfp:       "XFP",
+	common.IdentifierX2:        "X2",
+	common.IdentifierDwdmSfp:   "SFP",
+	common.IdentifierQsfp:      "QSFP",
+	common.IdentifierQsfpPlus:  "QSFP_PLUS",
+	common.IdentifierQsfp28:    "QSFP28",
+	common.IdentifierQsfpDd:    "QSFP56_DD",
+	common.IdentifierOsfp:      "OSFP",
+	common.IdentifierSfpDd:     "SFP_DD",
+	common.IdentifierDsfp:      "DSFP",
+	common.IdentifierMicroQsfp: "QSFP",
+}
+
+var connectorTypes = map[common.Connector]string{
+	common.ConnectorSc:          "SC_CONNECTOR",
+	common.ConnectorLc:          "LC_CONNECTOR",
+	common.ConnectorMpo:         "MPO_CONNECTOR",
+	common.ConnectorMpo2:        "MPO_CONNECTOR",
+	common.ConnectorMpo2x12:     "MPO_CONNECTOR",
+	common.ConnectorMpo1x16:     "MPO_CONNECTOR",
+	common.ConnectorCopperPtail: "DAC_CONNECTOR",
+	common.ConnectorNoSeparable: "AOC_CONNECTOR",
+}
+
+// ethernetPmds maps every PMD returned by the models to OpenConfig identities, PMDs without an
+// identity, such as 25G, 50G and Fibre Channel, map to ETH_UNDEFINED.
+var ethernetPmds = map[string]string{
+	"100BASE-FX":          "ETH_UNDEFINED",
+	"100BASE-LX10":        "ETH_UNDEFINED",
+	"BASE-BX10":           "ETH_UNDEFINED",
+	"BASE-PX":             "ETH_UNDEFINED",
+	"1000BASE-SX":         "ETH_UNDEFINED",
+	"1000BASE-LX":         "ETH_UNDEFINED",
+	"1000BASE-CX":         "ETH_UNDEFINED",
+	"1000BASE-T":          "ETH_UNDEFINED",
+	"1000BASE-ZX":         "ETH_UNDEFINED",
+	"2.5GBASE-T":          "ETH_UNDEFINED",
+	"5GBASE-T":            "ETH_UNDEFINED",
+	"10GBASE-T":           "ETH_UNDEFINED",
+	"10GBASE-CU":          "ETH_UNDEFINED",
+	"10GBASE-BR":          "ETH_UNDEFINED",
+	"10GBASE-SR":          "ETH_10GBASE_SR",
+	"10GBASE-LR":          "ETH_10GBASE_LR",
+	"10GBASE-LRM":         "ETH_10GBASE_LRM",
+	"10GBASE-ER":          "ETH_10GBASE_ER",
+	"10GBASE-ZR":          "ETH_10GBASE_ZR",
+	"25GBASE-CR":          "ETH_UNDEFINED",
+	"25GBASE-CR CA-25G-L": "ETH_UNDEFINED",
+	"25GBASE-CR CA-25G-S": "ETH_UNDEFINED",
+	"25GBASE-CR CA-25G-N": "ETH_UNDEFINED",
+	"25GBASE-SR":          "ETH_UNDEFINED",
+	"25GBASE-LR":          "ETH_UNDEFINED",
+	"25GBASE-ER":          "ETH_UNDEFINED",
+	"25GBASE-BR":          "ETH_UNDEFINED",
+	"25GAUI C2M AOC":      "ETH_UNDEFINED",
+	"25GAUI C2M ACC":      "ETH_UNDEFINED",
+	"40GBASE-SR4":         "ETH_40GBASE_SR4",
+	"40GBASE-LR4":         "ETH_40GBASE_LR4",
+	"40GBASE-CR4":         "ETH_40GBASE_CR4",
+	"40GBASE-ER4":         "ETH_40GBASE_ER4",
+	"40G PSM4":            "ETH_40GBASE_PSM4",
+	"40G SWDM4":           "ETH_UNDEFINED",
+	"4x10GBASE-SR":        "ETH_4X10GBASE_SR",
+	"50GBASE-CR":          "ETH_UNDEFINED",
+	"50GBASE-SR":          "ETH_UNDEFINED",
+	"50GBASE-FR":          "ETH_UNDEFINED",
+	"50GBASE-LR":          "ETH_UNDEFINED",
+	"50GBASE-ER":          "ETH_UNDEFINED",
+	"50GBASE-BR":          "ETH_UNDEFINED",
+	"50GAUI C2M AOC":      "ETH_UNDEFINED",
+	"50GAUI C2M ACC":      "ETH_UNDEFINED",
+	"100G AOC":            "ETH_100G_AOC",
+	"100G ACC":            "ETH_100G_ACC",
+	"100GBASE-SR4":        "ETH_100GBASE_SR4",
+	"100GBASE-LR4":        "ETH_100GBASE_LR4",
+	"100GBASE-ER4":        "ETH_100GBASE_ER4",
+	"100GBASE-SR10":       "ETH_100GBASE_SR10",
+	"100GBASE-CR4":        "ETH_100GBASE_CR4",
+	"100G CWDM4":          "ETH_100GBASE_CWDM4",
+	"100G CLR4":           "ETH_100GBASE_CLR4",
+	"100G PSM4":           "ETH_100GBASE_PSM4",
+	"100G SWDM4":          "ETH_UNDEFINED",
+	"100GBASE-DR":         "ETH_100GBASE_DR",
+	"100GBASE-FR1":        "ETH_100GBASE_FR",
+	"100GBASE-LR1":        "ETH_UNDEFINED",
+	"200GBASE-FR4":        "ETH_UNDEFINED",
+	"200GBASE-LR4":        "ETH_UNDEFINED",
+	"400GBASE-SR4":        "ETH_UNDEFINED",
+	"400GBASE-DR4":        "ETH_400GBASE_DR4",
+	"400GBASE-FR4":        "ETH_400GBASE_FR4",
+	"64GFC":               "ETH_UNDEFINED",
+	"128GFC":              "ETH_UNDEFINED",
+}
+
+// FormFactor returns the form factor identity, SFP modules are refined to SFP+, SFP28 or
+// SFP56 by nominal bit rate.
+func FormFactor(m *sff.Module) string {
+	id, ok := identity(m)
+	if !ok {
+		return FormFactorOther
+	}
+	if id == common.IdentifierSfp && m.Sff8079 != nil {
+		switch br := m.Sff8079.BrNominalMbps(); {
+		case br >= 50000:
+			return transportTypes + "SFP56"
+		case br >= 25000:
+			return transportTypes + "SFP28"
+		case br >= 8500:
+			return transportTypes + "SFP_PLUS"
+		}
+	}
+	if f, ok := formFactors[id]; ok {
+		return transportTypes + f
+	}
+	return FormFactorOther
+}
+
+// ConnectorType returns the connector type identity or an empty string if there is none.
+func ConnectorType(m *sff.Module) string {
+	var c common.Connector
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		c = m.Sff8079.Connector
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		c = m.Sff8636.Connector
+	default:
+		return ""
+	}
+	if t, ok := connectorTypes[c]; ok {
+		return transportTypes + t
+	}
+	return ""
+}
+
+// EthernetPmd returns the Ethernet PMD identity derived from the compliance codes.
+func EthernetPmd(m *sff.Module) string {
+	var pmd string
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		pmd = m.Sff8079.Pmd()
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		pmd = m.Sff8636.Pmd()
+	}
+	if p, ok := ethernetPmds[pmd]; ok {
+		return transportTypes + p
+	}
+	return PmdUndefined
+}
+
+func identity(m *sff.Module) (common.Identifier, bool) {
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		return m.Sff8079.Identifier, true
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		return m.Sff8636.Identifier, true
+	}
+	return 0, false
+}
+
+// Decimal is a decimal64 with 2 fraction digits, encoded as a string per RFC 7951.
+type Decimal float64
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatFloat(float64(d), 'f', 2, 64))
+}
+
+func (d *Decimal) UnmarshalJSON(in []byte) error {
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*d = Decimal(f)
+	return nil
+}
+
+func dbm(p common.Power) Decimal {
+	d := p.Dbm()
+	if math.IsInf(d, -1) || d < noPowerDbm {
+		return noPowerDbm
+	}
+	return Decimal(d)
+}
+
+// Instant is an instantaneous value.
+type Instant struct {
+	Instant Decimal `json:"instant"`
+}
+
+// State is the transceiver state.
+type State struct {
+	Present       string   `json:"present"`
+	FormFactor    string   `json:"form-factor"`
+	ConnectorType string   `json:"connector-type,omitempty"`
+	Vendor        string   `json:"vendor"`
+	VendorPart    string   `json:"vendor-part"`
+	VendorRev     string   `json:"vendor-rev"`
+	SerialNo      string   `json:"serial-no"`
+	DateCode      string   `json:"date-code,omitempty"`
+	EthernetPmd   string   `json:"ethernet-pmd"`
+	SupplyVoltage *Instant `json:"supply-voltage,omitempty"`
+}
+
+// ChannelState is the state of a physical channel, power is in dBm and bias current in mA.
+type ChannelState struct {
+	Index            int     `json:"index"`
+	InputPower       Instant `json:"input-power"`
+	OutputPower      Instant `json:"output-power"`
+	LaserBiasCurrent Instant `json:"laser-bias-current"`
+}
+
+// Channel is a physical channel, indexes start at 1 like the lanes elsewhere.
+type Channel struct {
+	Index int          `json:"index"`
+	State ChannelState `json:"state"`
+}
+
+// PhysicalChannels is the list of physical channels.
+type PhysicalChannels struct {
+	Channel []Channel `json:"channel"`
+}
+
+// ThresholdState is the upper and lower thresholds for a severity.
+type ThresholdState struct {
+	Severity               string  `json:"severity"`
+	ModuleTemperatureLower Decimal `json:"module-temperature-lower"`
+	ModuleTemperatureUpper Decimal `json:"module-temperature-upper"`
+	SupplyVoltageLower     Decimal `json:"supply-voltage-lower"`
+	SupplyVoltageUpper     Decimal `json:"supply-voltage-upper"`
+	InputPowerLower        Decimal `json:"input-power-lower"`
+	InputPowerUpper        Decimal `json:"input-power-upper"`
+	OutputPowerLower       Decimal `json:"output-power-lower"`
+	OutputPowerUpper       Decimal `json:"output-power-upper"`
+	LaserBiasCurrentLower  Decimal `json:"laser-bias-current-lower"`
+	LaserBiasCurrentUpper  Decimal `json:"laser-bias-current-upper"`
+}
+
+// Threshold is the thresholds for a severity, alarms are CRITICAL and warnings WARNING.
+type Threshold struct {
+	Severity string         `json:"severity"`
+	State    ThresholdState `json:"state"`
+}
+
+// Thresholds is the list of thresholds.
+type Thresholds struct {
+	Threshold []Threshold `json:"threshold"`
+}
+
+// Transceiver is the openconfig-platform-transceiver container.
+type Transceiver struct {
+	State            State            `json:"state"`
+	PhysicalChannels PhysicalChannels `json:"physical-channels"`
+	Thresholds       *Thresholds      `json:"thresholds,omitempty"`
+}
+
+// ComponentState is the platform component state.
+type ComponentState struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Temperature *Instant `json:"temperature,omitempty"`
+}
+
+// Component is a platform component with the transceiver augmentation.
+type Component struct {
+	Name        string         `json:"name"`
+	State       ComponentState `json:"state"`
+	Transceiver *Transceiver   `json:"openconfig-platform-transceiver:transceiver"`
+}
+
+// Components is the openconfig-platform components container.
+type Components struct {
+	Component []*Component `json:"component"`
+}
+
+type thresholds struct {
+	temp [4]common.Temperature
+	vcc  [4]common.Voltage
+	bias [4]common.Current
+	tx   [4]common.Power
+	rx   [4]common.Power
+}
+
+// list returns the thresholds, the arrays are high alarm, low alarm, high warning and low warning.
+func (t *thresholds) list() *Thresholds {
+	l := &Thresholds{}
+	for i, sev := range []string{"CRITICAL", "WARNING"} {
+		h, lo := i*2, i*2+1
+		l.Threshold = append(l.Threshold, Threshold{
+			Severity: alarmTypes + sev,
+			State: ThresholdState{
+				Severity:               alarmTypes + sev,
+				ModuleTemperatureLower: Decimal(t.temp[lo].Float64()),
+				ModuleTemperatureUpper: Decimal(t.temp[h].Float64()),
+				SupplyVoltageLower:     Decimal(t.vcc[lo].Float64()),
+				SupplyVoltageUpper:     Decimal(t.vcc[h].Float64()),
+				InputPowerLower:        dbm(t.rx[lo]),
+				InputPowerUpper:        dbm(t.rx[h]),
+				OutputPowerLower:       dbm(t.tx[lo]),
+				OutputPowerUpper:       dbm(t.tx[h]),
+				LaserBiasCurrentLower:  Decimal(t.bias[lo].Float64()),
+				LaserBiasCurrentUpper:  Decimal(t.bias[h].Float64()),
+			},
+		})
+	}
+	return l
+}
+
+func channel(i int, rx, tx common.Power, bias common.Current) Channel {
+	return Channel{Index: i, State: ChannelState{
+		Index:            i,
+		InputPower:       Instant{dbm(rx)},
+		OutputPower:      Instant{dbm(tx)},
+		LaserBiasCurrent: Instant{Decimal(bias.Float64())},
+	}}
+}
+
+func dateCode(d common.DateCode) string {
+	t, err := d.Time()
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// NewTransceiver returns the transceiver state for the module.
+func NewTransceiver(m *sff.Module) *Transceiver {
+	t := &Transceiver{
+		State: State{
+			Present:       "PRESENT",
+			FormFactor:    FormFactor(m),
+			ConnectorType: ConnectorType(m),
+			EthernetPmd:   EthernetPmd(m),
+		},
+		PhysicalChannels: PhysicalChannels{Channel: []Channel{}},
+	}
+
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		t.State.Vendor, t.State.VendorPart, t.State.VendorRev, t.State.SerialNo = s.Vendor.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		t.State.DateCode = dateCode(s.DateCode)
+
+		if d := m.Diagnostics(); d != nil {
+			t.State.SupplyVoltage = &Instant{Decimal(d.Vcc.Float64())}
+			t.PhysicalChannels.Channel = append(t.PhysicalChannels.Channel, channel(1, d.RxPower, d.TxPower, d.TxBias))
+			t.Thresholds = (&thresholds{
+				temp: [4]common.Temperature{d.TempHighAlarm, d.TempLowAlarm, d.TempHighWarning, d.TempLowWarning},
+				vcc:  [4]common.Voltage{d.VccHighAlarm, d.VccLowAlarm, d.VccHighWarning, d.VccLowWarning},
+				bias: [4]common.Current{d.BiasHighAlarm, d.BiasLowAlarm, d.BiasHighWarning, d.BiasLowWarning},
+				tx:   [4]common.Power{d.TxPwrHighAlarm, d.TxPwrLowAlarm, d.TxPwrHighWarning, d.TxPwrLowWarning},
+				rx:   [4]common.Power{d.RxPwrHighAlarm, d.RxPwrLowAlarm, d.RxPwrHighWarning, d.RxPwrLowWarning},
+			}).list()
+		}
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		t.State.Vendor, t.State.VendorPart, t.State.VendorRev, t.State.SerialNo = s.Vendor.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String()
+		t.State.DateCode = dateCode(s.DateCode)
+
+		if l := m.LowerPage; l != nil {
+			t.State.SupplyVoltage = &Instant{Decimal(l.Vcc.Float64())}
+			for i := 0; i < 4; i++ {
+				t.PhysicalChannels.Channel = append(t.PhysicalChannels.Channel, channel(i+1, l.RxPower[i], l.TxPower[i], l.TxBias[i]))
+			}
+		}
+		if d := m.Thresholds; d != nil {
+			t.Thresholds = (&thresholds{
+				temp: [4]common.Temperature{d.TempHighAlarm, d.TempLowAlarm, d.TempHighWarning, d.TempLowWarning},
+				vcc:  [4]common.Voltage{d.VccHighAlarm, d.VccLowAlarm, d.VccHighWarning, d.VccLowWarning},
+				bias: [4]common.Current{d.BiasHighAlarm, d.BiasLowAlarm, d.BiasHighWarning, d.BiasLowWarning},
+				tx:   [4]common.Power{d.TxPwrHighAlarm, d.TxPwrLowAlarm, d.TxPwrHighWarning, d.TxPwrLowWarning},
+				rx:   [4]common.Power{d.RxPwrHighAlarm, d.RxPwrLowAlarm, d.RxPwrHighWarning, d.RxPwrLowWarning},
+			}).list()
+		}
+	}
+	return t
+}
+
+// NewComponent returns a platform component named after the port with the transceiver state.
+func NewComponent(name string, m *sff.Module) *Component {
+	c := &Component{
+		Name:        name,
+		State:       ComponentState{Name: name, Type: "openconfig-platform-types:TRANSCEIVER"},
+		Transceiver: NewTransceiver(m),
+	}
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8472 != nil:
+		c.State.Temperature = &Instant{Decimal(m.Diagnostics().Temperature.Float64())}
+	case m.Type == sff.TypeSff8636 && m.LowerPage != nil:
+		c.State.Temperature = &Instant{Decimal(m.LowerPage.Temperature.Float64())}
+	}
+	return c
+}
+
+// Marshal returns the openconfig-platform components JSON for the modules by component name.
+func Marshal(modules map[string]*sff.Module) ([]byte, error) {
+	names := []string{}
+	for n := range modules {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	c := Components{Component: []*Component{}}
+	for _, n := range names {
+		c.Component = append(c.Component, NewComponent(n, modules[n]))
+	}
+	return json.MarshalIndent(map[string]Components{"openconfig-platform:components": c}, "", "  ")
+}
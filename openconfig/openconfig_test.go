@@ -0,0 +1,187 @@
+package openconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/sff8079"
+	"github.com/mickep76/go-sff/sff8636"
+)
+
+func TestEthernetPmds(t *testing.T) {
+	for model, pmds := range map[string][]string{"SFF-8079": sff8079.Pmds(), "SFF-8636": sff8636.Pmds()} {
+		if len(pmds) == 0 {
+			t.Errorf("%s: no PMDs", model)
+		}
+		for _, p := range pmds {
+			if _, ok := ethernetPmds[p]; !ok {
+				t.Errorf("%s: PMD %q has no OpenConfig identity", model, p)
+			}
+		}
+	}
+}
+
+// sfp returns a 10G SFP+ with diagnostics, the thresholds are in A2h bytes 0-39.
+func sfp(t *testing.T) *sff.Module {
+	t.Helper()
+
+	b := make([]byte, 512)
+	b[0], b[1], b[2], b[3], b[12], b[92] = 0x03, 0x04, byte(common.ConnectorLc), 0x10, 103, 0x60
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[56:60], "A   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	copy(b[256:], []byte{
+		0x50, 0x00, 0xf6, 0x00, 0x4b, 0x00, 0xfb, 0x00, // 80 C, -10 C, 75 C, -5 C
+		0x8d, 0xcc, 0x74, 0x04, 0x88, 0xb8, 0x79, 0x18, // 3.63 V, 2.97 V, 3.5 V, 3.1 V
+		0x3a, 0x98, 0x01, 0xf4, 0x32, 0xc8, 0x03, 0xe8, // 30 mA, 1 mA, 26 mA, 2 mA
+		0x27, 0x10, 0x01, 0xf4, 0x1f, 0x40, 0x03, 0xe8, // TX 1 mW, 0.05 mW, 0.8 mW, 0.1 mW
+		0x27, 0x10, 0x00, 0x00, 0x1f, 0x40, 0x00, 0x64, // RX 1 mW, 0 mW, 0.8 mW, 0.01 mW
+	})
+	copy(b[256+96:], []byte{
+		0x21, 0x80, // 33.5 C
+		0x80, 0xe8, // 3.3 V
+		0x17, 0x70, // 12 mA
+		0x13, 0x88, // 0.5 mW
+		0x0f, 0xa0, // 0.4 mW
+	})
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// qsfp returns a QSFP28 100GBASE-SR4 with the lower page, page 00h and page 03h.
+func qsfp(t *testing.T) *sff.Module {
+	t.Helper()
+
+	b := make([]byte, 640)
+	b[0], b[128], b[130], b[192] = 0x11, 0x11, byte(common.ConnectorMpo), common.ExtCompliance100gSr4
+	copy(b[148:164], "FINISAR CORP.   ")
+	copy(b[168:184], "FTLC9551REPM    ")
+	copy(b[184:186], "A0")
+	copy(b[196:212], "ALA5678         ")
+	copy(b[212:220], "200101  ")
+	b[22], b[23] = 0x21, 0x80
+	b[26], b[27] = 0x80, 0xe8
+	for i := 0; i < 4; i++ {
+		b[34+i*2], b[35+i*2] = 0x13, 0x88 // RX 0.5 mW
+		b[42+i*2], b[43+i*2] = 0x17, 0x70 // 12 mA
+		b[50+i*2], b[51+i*2] = 0x0f, 0xa0 // TX 0.4 mW
+	}
+	b[35] = 0x00 // RX channel 1 0.4864 mW
+	b[40], b[41] = 0x00, 0x00
+	b[384+128], b[384+130] = 0x50, 0xf6 // 80 C, -10 C
+	b[384+176], b[384+177] = 0x27, 0x10 // RX 1 mW
+	b[384+184], b[384+185] = 0x3a, 0x98 // 30 mA
+	b[384+196], b[384+197] = 0x1f, 0x40 // TX 0.8 mW
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMarshal(t *testing.T) {
+	b, err := Marshal(map[string]*sff.Module{"et2": qsfp(t), "et1": sfp(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := map[string]Components{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	l := v["openconfig-platform:components"].Component
+	if len(l) != 2 || l[0].Name != "et1" || l[1].Name != "et2" {
+		t.Fatalf("got components %s, want et1 and et2", b)
+	}
+	s, q := l[0], l[1]
+
+	for _, c := range []*Component{s, q} {
+		if c.State.Type != "openconfig-platform-types:TRANSCEIVER" || c.State.Temperature == nil || c.State.Temperature.Instant != 33.5 {
+			t.Errorf("%s: got state %+v, want a transceiver at 33.50 C", c.Name, c.State)
+		}
+		if c.Transceiver == nil || c.Transceiver.State.SupplyVoltage == nil || c.Transceiver.State.SupplyVoltage.Instant != 3.3 {
+			t.Fatalf("%s: got no transceiver or supply voltage", c.Name)
+		}
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"et1 form factor", s.Transceiver.State.FormFactor, transportTypes + "SFP_PLUS"},
+		{"et1 connector", s.Transceiver.State.ConnectorType, transportTypes + "LC_CONNECTOR"},
+		{"et1 pmd", s.Transceiver.State.EthernetPmd, transportTypes + "ETH_10GBASE_SR"},
+		{"et1 vendor", s.Transceiver.State.Vendor, "FINISAR CORP."},
+		{"et1 date code", s.Transceiver.State.DateCode, "2019-01-01T00:00:00Z"},
+		{"et2 form factor", q.Transceiver.State.FormFactor, transportTypes + "QSFP28"},
+		{"et2 connector", q.Transceiver.State.ConnectorType, transportTypes + "MPO_CONNECTOR"},
+		{"et2 pmd", q.Transceiver.State.EthernetPmd, transportTypes + "ETH_100GBASE_SR4"},
+		{"et2 serial", q.Transceiver.State.SerialNo, "ALA5678"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	channels := []struct {
+		name string
+		got  []Channel
+		want []ChannelState
+	}{
+		{"et1", s.Transceiver.PhysicalChannels.Channel, []ChannelState{{1, Instant{-3.98}, Instant{-3.01}, Instant{12}}}},
+		{"et2", q.Transceiver.PhysicalChannels.Channel, []ChannelState{
+			{1, Instant{-3.13}, Instant{-3.98}, Instant{12}},
+			{2, Instant{-3.01}, Instant{-3.98}, Instant{12}},
+			{3, Instant{-3.01}, Instant{-3.98}, Instant{12}},
+			{4, Instant{noPowerDbm}, Instant{-3.98}, Instant{12}},
+		}},
+	}
+	for _, tt := range channels {
+		if len(tt.got) != len(tt.want) {
+			t.Errorf("%s: got %d channels, want %d", tt.name, len(tt.got), len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			if g := tt.got[i]; g.Index != w.Index || g.State != w {
+				t.Errorf("%s: got channel %+v, want %+v", tt.name, g, w)
+			}
+		}
+	}
+
+	thresholds := []struct {
+		name string
+		got  *Thresholds
+		want []ThresholdState
+	}{
+		{"et1", s.Transceiver.Thresholds, []ThresholdState{
+			{alarmTypes + "CRITICAL", -10, 80, 2.97, 3.63, noPowerDbm, 0, -13.01, 0, 1, 30},
+			{alarmTypes + "WARNING", -5, 75, 3.1, 3.5, -20, -0.97, -10, -0.97, 2, 26},
+		}},
+		{"et2", q.Transceiver.Thresholds, []ThresholdState{
+			{alarmTypes + "CRITICAL", -10, 80, 0, 0, noPowerDbm, 0, noPowerDbm, noPowerDbm, 0, 30},
+			{alarmTypes + "WARNING", 0, 0, 0, 0, noPowerDbm, noPowerDbm, noPowerDbm, -0.97, 0, 0},
+		}},
+	}
+	for _, tt := range thresholds {
+		if tt.got == nil || len(tt.got.Threshold) != len(tt.want) {
+			t.Errorf("%s: got thresholds %+v, want %d severities", tt.name, tt.got, len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			if g := tt.got.Threshold[i]; g.Severity != w.Severity || g.State != w {
+				t.Errorf("%s: got threshold %+v, want %+v", tt.name, g.State, w)
+			}
+		}
+	}
+}
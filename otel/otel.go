@@ -0,0 +1,151 @@
+// Package otel registers module diagnostics as OpenTelemetry observable gauges.
+//
+// Any meter provider can be used, such as the SDK with an OTLP or in-memory exporter.
+package otel
+
+import (
+	"context"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/batch"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Attribute keys.
+const (
+	SourceKey     = attribute.Key("sff.source")
+	TypeKey       = attribute.Key("sff.type")
+	IdentifierKey = attribute.Key("sff.identifier")
+	VendorKey     = attribute.Key("sff.vendor")
+	VendorPnKey   = attribute.Key("sff.vendor_pn")
+	VendorRevKey  = attribute.Key("sff.vendor_rev")
+	VendorSnKey   = attribute.Key("sff.vendor_sn")
+	LaneKey       = attribute.Key("sff.lane")
+)
+
+// Attributes returns the module identity.
+func Attributes(m *sff.Module) []attribute.KeyValue {
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		return []attribute.KeyValue{
+			TypeKey.String(string(m.Type)),
+			IdentifierKey.String(s.Identifier.String()),
+			VendorKey.String(s.Vendor.String()),
+			VendorPnKey.String(s.VendorPn.String()),
+			VendorRevKey.String(s.VendorRev.String()),
+			VendorSnKey.String(s.VendorSn.String()),
+		}
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		return []attribute.KeyValue{
+			TypeKey.String(string(m.Type)),
+			IdentifierKey.String(s.Identifier.String()),
+			VendorKey.String(s.Vendor.String()),
+			VendorPnKey.String(s.VendorPn.String()),
+			VendorRevKey.String(s.VendorRev.String()),
+			VendorSnKey.String(s.VendorSn.String()),
+		}
+	}
+	return []attribute.KeyValue{TypeKey.String(string(m.Type))}
+}
+
+// Resource returns the module identity as a resource, for pollers exporting a single module.
+func Resource(m *sff.Module) *resource.Resource {
+	return resource.NewSchemaless(Attributes(m)...)
+}
+
+// lane values.
+type lane struct {
+	bias, tx, rx, txDbm, rxDbm float64
+}
+
+type gauges struct {
+	up, temp, vcc, bias, tx, txDbm, rx, rxDbm metric.Float64ObservableGauge
+}
+
+func newGauges(meter metric.Meter) (*gauges, error) {
+	g := &gauges{}
+	var err error
+	for _, i := range []struct {
+		g     *metric.Float64ObservableGauge
+		name  string
+		descr string
+		unit  string
+	}{
+		{&g.up, "sff.up", "Whether the module was read and decoded", "1"},
+		{&g.temp, "sff.temperature", "Module temperature", "Cel"},
+		{&g.vcc, "sff.voltage", "Module supply voltage", "V"},
+		{&g.bias, "sff.tx_bias", "Laser bias current per lane", "mA"},
+		{&g.tx, "sff.tx_power", "Transmit power per lane", "mW"},
+		{&g.txDbm, "sff.tx_power.dbm", "Transmit power per lane", "dBm"},
+		{&g.rx, "sff.rx_power", "Receive power per lane", "mW"},
+		{&g.rxDbm, "sff.rx_power.dbm", "Receive power per lane", "dBm"},
+	} {
+		if *i.g, err = meter.Float64ObservableGauge(i.name, metric.WithDescription(i.descr), metric.WithUnit(i.unit)); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+func (g *gauges) instruments() []metric.Observable {
+	return []metric.Observable{g.up, g.temp, g.vcc, g.bias, g.tx, g.txDbm, g.rx, g.rxDbm}
+}
+
+// Register registers observable gauges reading the sources on each collection.
+// Unregister the returned registration to stop reading the sources.
+func Register(meter metric.Meter, sources ...batch.Source) (metric.Registration, error) {
+	g, err := newGauges(meter)
+	if err != nil {
+		return nil, err
+	}
+	return meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		for _, s := range sources {
+			g.observe(o, s)
+		}
+		return nil
+	}, g.instruments()...)
+}
+
+func (g *gauges) observe(o metric.Observer, s batch.Source) {
+	m, err := s.Read()
+	if err != nil {
+		o.ObserveFloat64(g.up, 0, metric.WithAttributes(SourceKey.String(s.Name)))
+		return
+	}
+
+	attrs := append([]attribute.KeyValue{SourceKey.String(s.Name)}, Attributes(m)...)
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	o.ObserveFloat64(g.up, 1, set)
+
+	var temp, vcc float64
+	lanes := []lane{}
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8472 != nil:
+		d := m.Diagnostics()
+		temp, vcc = d.Temperature.Float64(), d.Vcc.Float64()
+		lanes = append(lanes, lane{d.TxBias.Float64(), d.TxPower.Float64(), d.RxPower.Float64(), d.TxPower.Dbm(), d.RxPower.Dbm()})
+	case m.Type == sff.TypeSff8636 && m.LowerPage != nil:
+		l := m.LowerPage
+		temp, vcc = l.Temperature.Float64(), l.Vcc.Float64()
+		for i := 0; i < 4; i++ {
+			lanes = append(lanes, lane{l.TxBias[i].Float64(), l.TxPower[i].Float64(), l.RxPower[i].Float64(), l.TxPower[i].Dbm(), l.RxPower[i].Dbm()})
+		}
+	default:
+		return
+	}
+
+	o.ObserveFloat64(g.temp, temp, set)
+	o.ObserveFloat64(g.vcc, vcc, set)
+	for i, l := range lanes {
+		set := metric.WithAttributeSet(attribute.NewSet(append(attrs, LaneKey.Int(i+1))...))
+		o.ObserveFloat64(g.bias, l.bias, set)
+		o.ObserveFloat64(g.tx, l.tx, set)
+		o.ObserveFloat64(g.txDbm, l.txDbm, set)
+		o.ObserveFloat64(g.rx, l.rx, set)
+		o.ObserveFloat64(g.rxDbm, l.rxDbm, set)
+	}
+}
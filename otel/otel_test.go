@@ -0,0 +1,148 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/batch"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// sfp returns an SFP module with internally calibrated diagnostics.
+func sfp(t *testing.T) *sff.Module {
+	t.Helper()
+
+	b := make([]byte, 512)
+	b[0], b[1], b[92] = 0x03, 0x04, 0x60
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[56:60], "A   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	copy(b[256+96:], []byte{
+		0x21, 0x80, // 33.5 C
+		0x80, 0xe8, // 3.3 V
+		0x17, 0x70, // 12 mA
+		0x13, 0x88, // 0.5 mW
+		0x0f, 0xa0, // 0.4 mW
+	})
+
+	m, err := sff.Decode(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// collect returns the gauge data points by metric name.
+func collect(t *testing.T, sources ...batch.Source) map[string][]metricdata.DataPoint[float64] {
+	t.Helper()
+
+	r := sdkmetric.NewManualReader()
+	p := sdkmetric.NewMeterProvider(sdkmetric.WithReader(r))
+	defer p.Shutdown(context.Background())
+
+	reg, err := Register(p.Meter("sff"), sources...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reg.Unregister()
+
+	rm := metricdata.ResourceMetrics{}
+	if err := r.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	points := map[string][]metricdata.DataPoint[float64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			g, ok := m.Data.(metricdata.Gauge[float64])
+			if !ok {
+				t.Fatalf("%s: got %T, want gauge", m.Name, m.Data)
+			}
+			points[m.Name] = append(points[m.Name], g.DataPoints...)
+		}
+	}
+	return points
+}
+
+func TestRegister(t *testing.T) {
+	m := sfp(t)
+	points := collect(t,
+		batch.Source{Name: "et1", Read: func() (*sff.Module, error) { return m, nil }},
+		batch.Source{Name: "et2", Read: func() (*sff.Module, error) { return nil, errors.New("no module") }},
+	)
+
+	module := attribute.NewSet(
+		SourceKey.String("et1"),
+		TypeKey.String(string(sff.TypeSff8079)),
+		IdentifierKey.String(m.Sff8079.Identifier.String()),
+		VendorKey.String("FINISAR CORP."),
+		VendorPnKey.String("FTLX8571D3BCL"),
+		VendorRevKey.String("A"),
+		VendorSnKey.String("ALA1234"),
+	)
+	lane := attribute.NewSet(append(module.ToSlice(), LaneKey.Int(1))...)
+	failed := attribute.NewSet(SourceKey.String("et2"))
+
+	tests := []struct {
+		name  string
+		attrs attribute.Set
+		value float64
+	}{
+		{"sff.temperature", module, 33.5},
+		{"sff.voltage", module, 3.3},
+		{"sff.tx_bias", lane, 12},
+		{"sff.tx_power", lane, 0.5},
+		{"sff.tx_power.dbm", lane, 10 * math.Log10(0.5)},
+		{"sff.rx_power", lane, 0.4},
+		{"sff.rx_power.dbm", lane, 10 * math.Log10(0.4)},
+	}
+	for _, tt := range tests {
+		l := points[tt.name]
+		if len(l) != 1 {
+			t.Errorf("%s: got %d data points, want 1", tt.name, len(l))
+			continue
+		}
+		if !l[0].Attributes.Equals(&tt.attrs) {
+			t.Errorf("%s: got attributes %v, want %v", tt.name, l[0].Attributes.ToSlice(), tt.attrs.ToSlice())
+		}
+		if math.Abs(l[0].Value-tt.value) > 1e-3 {
+			t.Errorf("%s: got %v, want %v", tt.name, l[0].Value, tt.value)
+		}
+	}
+
+	up := map[attribute.Distinct]float64{}
+	for _, p := range points["sff.up"] {
+		up[p.Attributes.Equivalent()] = p.Value
+	}
+	if len(up) != 2 {
+		t.Fatalf("sff.up: got %d data points, want 2", len(up))
+	}
+	if v, ok := up[module.Equivalent()]; !ok || v != 1 {
+		t.Errorf("sff.up: got %v for et1, want 1", v)
+	}
+	if v, ok := up[failed.Equivalent()]; !ok || v != 0 {
+		t.Errorf("sff.up: got %v for et2, want 0", v)
+	}
+}
+
+func TestRegisterNoDiagnostics(t *testing.T) {
+	m := sfp(t)
+	m.Sff8472 = nil
+	points := collect(t, batch.Source{Name: "et1", Read: func() (*sff.Module, error) { return m, nil }})
+
+	if l := points["sff.up"]; len(l) != 1 || l[0].Value != 1 {
+		t.Errorf("sff.up: got %v, want a single 1", l)
+	}
+	for _, name := range []string{"sff.temperature", "sff.voltage", "sff.tx_bias", "sff.tx_power", "sff.rx_power"} {
+		if l := points[name]; len(l) != 0 {
+			t.Errorf("%s: got %d data points, want 0", name, len(l))
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package prometheus exports module diagnostics as Prometheus metrics.
+package prometheus
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/mickep76/go-sff"
+	"github.com/mickep76/go-sff/batch"
+	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/sff8472"
+	"github.com/mickep76/go-sff/sff8636"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "sff"
+
+var levels = []string{"high_alarm", "low_alarm", "high_warning", "low_warning"}
+
+func desc(name, help string, labels ...string) *prom.Desc {
+	return prom.NewDesc(prom.BuildFQName(namespace, "", name), help, append([]string{"source"}, labels...), nil)
+}
+
+var (
+	upDesc          = desc("up", "Whether the module was read and decoded.")
+	infoDesc        = desc("module_info", "Module identity.", "type", "identifier", "vendor", "vendor_pn", "vendor_rev", "vendor_sn")
+	tempDesc        = desc("temperature_celsius", "Module temperature.")
+	vccDesc         = desc("voltage_volts", "Module supply voltage.")
+	biasDesc        = desc("tx_bias_milliamperes", "Laser bias current per lane.", "lane")
+	txMwDesc        = desc("tx_power_milliwatts", "Transmit power per lane.", "lane")
+	txDbmDesc       = desc("tx_power_dbm", "Transmit power per lane, not exported at 0 mW.", "lane")
+	rxMwDesc        = desc("rx_power_milliwatts", "Receive power per lane.", "lane")
+	rxDbmDesc       = desc("rx_power_dbm", "Receive power per lane, not exported at 0 mW.", "lane")
+	tempThreshDesc  = desc("temperature_threshold_celsius", "Temperature alarm and warning thresholds.", "level")
+	vccThreshDesc   = desc("voltage_threshold_volts", "Supply voltage alarm and warning thresholds.", "level")
+	biasThreshDesc  = desc("tx_bias_threshold_milliamperes", "Laser bias current alarm and warning thresholds.", "level")
+	txMwThreshDesc  = desc("tx_power_threshold_milliwatts", "Transmit power alarm and warning thresholds.", "level")
+	txDbmThreshDesc = desc("tx_power_threshold_dbm", "Transmit power alarm and warning thresholds, not exported at 0 mW.", "level")
+	rxMwThreshDesc  = desc("rx_power_threshold_milliwatts", "Receive power alarm and warning thresholds.", "level")
+	rxDbmThreshDesc = desc("rx_power_threshold_dbm", "Receive power alarm and warning thresholds, not exported at 0 mW.", "level")
+	flagDesc        = desc("flag", "Alarm and warning flag state, 1 if set.", "severity", "flag")
+)
+
+// Collector reads the sources on each scrape.
+type Collector struct {
+	Sources []batch.Source
+}
+
+// NewCollector returns a collector for the sources.
+func NewCollector(sources ...batch.Source) *Collector {
+	return &Collector{Sources: sources}
+}
+
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, d := range []*prom.Desc{upDesc, infoDesc, tempDesc, vccDesc, biasDesc, txMwDesc, txDbmDesc, rxMwDesc, rxDbmDesc,
+		tempThreshDesc, vccThreshDesc, biasThreshDesc, txMwThreshDesc, txDbmThreshDesc, rxMwThreshDesc, rxDbmThreshDesc, flagDesc} {
+		ch <- d
+	}
+}
+
+// Collect reads the sources in parallel.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	wg := sync.WaitGroup{}
+	for _, s := range c.Sources {
+		wg.Add(1)
+		go func(s batch.Source) {
+			defer wg.Done()
+			m, err := s.Read()
+			if err != nil {
+				ch <- prom.MustNewConstMetric(upDesc, prom.GaugeValue, 0, s.Name)
+				return
+			}
+			ch <- prom.MustNewConstMetric(upDesc, prom.GaugeValue, 1, s.Name)
+			collect(ch, s.Name, m)
+		}(s)
+	}
+	wg.Wait()
+}
+
+func gauge(ch chan<- prom.Metric, d *prom.Desc, v float64, labels ...string) {
+	ch <- prom.MustNewConstMetric(d, prom.GaugeValue, v, labels...)
+}
+
+// dbm exports the power in dBm, it's skipped at 0 mW which is -Inf dBm. The milliwatt metric
+// is always exported.
+func dbm(ch chan<- prom.Metric, d *prom.Desc, p common.Power, labels ...string) {
+	if p.Float64() == 0 {
+		return
+	}
+	gauge(ch, d, p.Dbm(), labels...)
+}
+
+// thresholds are high alarm, low alarm, high warning and low warning.
+type thresholds struct {
+	temp [4]common.Temperature
+	vcc  [4]common.Voltage
+	bias [4]common.Current
+	tx   [4]common.Power
+	rx   [4]common.Power
+}
+
+func (t *thresholds) collect(ch chan<- prom.Metric, source string) {
+	for i, l := range levels {
+		gauge(ch, tempThreshDesc, t.temp[i].Float64(), source, l)
+		gauge(ch, vccThreshDesc, t.vcc[i].Float64(), source, l)
+		gauge(ch, biasThreshDesc, t.bias[i].Float64(), source, l)
+		gauge(ch, txMwThreshDesc, t.tx[i].Float64(), source, l)
+		dbm(ch, txDbmThreshDesc, t.tx[i], source, l)
+		gauge(ch, rxMwThreshDesc, t.rx[i].Float64(), source, l)
+		dbm(ch, rxDbmThreshDesc, t.rx[i], source, l)
+	}
+}
+
+// flags exports all flags, all is the list with every flag set and active the current flags.
+func flags(ch chan<- prom.Metric, source string, severity string, all []string, active []string) {
+	set := map[string]bool{}
+	for _, f := range active {
+		set[f] = true
+	}
+	for _, f := range all {
+		v := 0.0
+		if set[f] {
+			v = 1
+		}
+		gauge(ch, flagDesc, v, source, severity, f)
+	}
+}
+
+func collect(ch chan<- prom.Metric, source string, m *sff.Module) {
+	switch {
+	case m.Type == sff.TypeSff8079 && m.Sff8079 != nil:
+		s := m.Sff8079
+		gauge(ch, infoDesc, 1, source, string(m.Type), s.Identifier.String(), s.Vendor.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String())
+
+		d := m.Diagnostics()
+		if d == nil {
+			return
+		}
+		gauge(ch, tempDesc, d.Temperature.Float64(), source)
+		gauge(ch, vccDesc, d.Vcc.Float64(), source)
+		gauge(ch, biasDesc, d.TxBias.Float64(), source, "1")
+		gauge(ch, txMwDesc, d.TxPower.Float64(), source, "1")
+		dbm(ch, txDbmDesc, d.TxPower, source, "1")
+		gauge(ch, rxMwDesc, d.RxPower.Float64(), source, "1")
+		dbm(ch, rxDbmDesc, d.RxPower, source, "1")
+
+		(&thresholds{
+			temp: [4]common.Temperature{d.TempHighAlarm, d.TempLowAlarm, d.TempHighWarning, d.TempLowWarning},
+			vcc:  [4]common.Voltage{d.VccHighAlarm, d.VccLowAlarm, d.VccHighWarning, d.VccLowWarning},
+			bias: [4]common.Current{d.BiasHighAlarm, d.BiasLowAlarm, d.BiasHighWarning, d.BiasLowWarning},
+			tx:   [4]common.Power{d.TxPwrHighAlarm, d.TxPwrLowAlarm, d.TxPwrHighWarning, d.TxPwrLowWarning},
+			rx:   [4]common.Power{d.RxPwrHighAlarm, d.RxPwrLowAlarm, d.RxPwrHighWarning, d.RxPwrLowWarning},
+		}).collect(ch, source)
+
+		all := sff8472.Flags{0xff, 0xff}.List()
+		flags(ch, source, "alarm", all, d.AlarmFlags.List())
+		flags(ch, source, "warning", all, d.WarningFlags.List())
+	case m.Type == sff.TypeSff8636 && m.Sff8636 != nil:
+		s := m.Sff8636
+		gauge(ch, infoDesc, 1, source, string(m.Type), s.Identifier.String(), s.Vendor.String(), s.VendorPn.String(), s.VendorRev.String(), s.VendorSn.String())
+
+		l := m.LowerPage
+		if l == nil {
+			return
+		}
+		gauge(ch, tempDesc, l.Temperature.Float64(), source)
+		gauge(ch, vccDesc, l.Vcc.Float64(), source)
+		for i := 0; i < 4; i++ {
+			lane := strconv.Itoa(i + 1)
+			gauge(ch, biasDesc, l.TxBias[i].Float64(), source, lane)
+			gauge(ch, txMwDesc, l.TxPower[i].Float64(), source, lane)
+			dbm(ch, txDbmDesc, l.TxPower[i], source, lane)
+			gauge(ch, rxMwDesc, l.RxPower[i].Float64(), source, lane)
+			dbm(ch, rxDbmDesc, l.RxPower[i], source, lane)
+		}
+
+		if t := m.Thresholds; t != nil {
+			(&thresholds{
+				temp: [4]common.Temperature{t.TempHighAlarm, t.TempLowAlarm, t.TempHighWarning, t.TempLowWarning},
+				vcc:  [4]common.Voltage{t.VccHighAlarm, t.VccLowAlarm, t.VccHighWarning, t.VccLowWarning},
+				bias: [4]common.Current{t.BiasHighAlarm, t.BiasLowAlarm, t.BiasHighWarning, t.BiasLowWarning},
+				tx:   [4]common.Power{t.TxPwrHighAlarm, t.TxPwrLowAlarm, t.TxPwrHighWarning, t.TxPwrLowWarning},
+				rx:   [4]common.Power{t.RxPwrHighAlarm, t.RxPwrLowAlarm, t.RxPwrHighWarning, t.RxPwrLowWarning},
+			}).collect(ch, source)
+		}
+
+		all := &sff8636.LowerPage{TempFlags: 0xf0, VccFlags: 0xf0, RxPowerFlags: sff8636.ChannelFlags{0xff, 0xff},
+			TxBiasFlags: sff8636.ChannelFlags{0xff, 0xff}, TxPowerFlags: sff8636.ChannelFlags{0xff, 0xff}}
+		flags(ch, source, "alarm", all.Alarms(), l.Alarms())
+		flags(ch, source, "warning", all.Warnings(), l.Warnings())
+	}
+}
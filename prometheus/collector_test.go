@@ -0,0 +1,143 @@
+package prometheus
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mickep76/go-sff/batch"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// sfp returns an SFP with internally calibrated diagnostics, no RX power and only temperature
+// thresholds.
+func sfp() []byte {
+	b := make([]byte, 512)
+	b[0], b[1], b[92] = 0x03, 0x04, 0x60
+	copy(b[20:36], "FINISAR CORP.   ")
+	copy(b[40:56], "FTLX8571D3BCL   ")
+	copy(b[56:60], "A   ")
+	copy(b[68:84], "ALA1234         ")
+	copy(b[84:92], "190101  ")
+	copy(b[256:], []byte{0x50, 0x00, 0xf6, 0x00, 0x4b, 0x00, 0xfb, 0x00}) // 80 C, -10 C, 75 C, -5 C
+	copy(b[256+96:], []byte{
+		0x21, 0x80, // 33.5 C
+		0x80, 0xe8, // 3.3 V
+		0x17, 0x70, // 12 mA
+		0x13, 0x88, // 0.5 mW
+		0x00, 0x00, // 0 mW
+	})
+	return b
+}
+
+// qsfp returns a QSFP28 with the lower page, page 00h and page 03h, lane 4 has no RX power
+// and only the RX power high alarm threshold is set.
+func qsfp() []byte {
+	b := make([]byte, 640)
+	b[0], b[128] = 0x11, 0x11
+	copy(b[148:164], "FINISAR CORP.   ")
+	copy(b[168:184], "FTLC9551REPM    ")
+	copy(b[184:186], "A0")
+	copy(b[196:212], "ALA5678         ")
+	copy(b[212:220], "200101  ")
+	b[22], b[23] = 0x1e, 0x00 // 30 C
+	b[26], b[27] = 0x80, 0xe8 // 3.3 V
+	for i := 0; i < 3; i++ {
+		b[34+i*2], b[35+i*2] = 0x27, 0x10 // RX 1 mW
+	}
+	for i := 0; i < 4; i++ {
+		b[42+i*2], b[43+i*2] = 0x17, 0x70 // 12 mA
+		b[50+i*2], b[51+i*2] = 0x13, 0x88 // TX 0.5 mW
+	}
+	b[384+176], b[384+177] = 0x27, 0x10 // RX high alarm 1 mW
+	return b
+}
+
+func TestCollector(t *testing.T) {
+	dir := t.TempDir()
+	for name, b := range map[string][]byte{"sfp.bin": sfp(), "qsfp.bin": qsfp()} {
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewCollector(
+		batch.File(filepath.Join(dir, "sfp.bin")),
+		batch.File(filepath.Join(dir, "qsfp.bin")),
+		batch.File(filepath.Join(dir, "missing.bin")),
+	)
+
+	// The dBm metrics are missing for the SFP RX power, QSFP lane 4 and thresholds at 0 mW.
+	want := strings.ReplaceAll(`
+# HELP sff_module_info Module identity.
+# TYPE sff_module_info gauge
+sff_module_info{identifier="QSFP28",source="DIR/qsfp.bin",type="SFF-8636",vendor="FINISAR CORP.",vendor_pn="FTLC9551REPM",vendor_rev="A0",vendor_sn="ALA5678"} 1
+sff_module_info{identifier="SFP",source="DIR/sfp.bin",type="SFF-8079",vendor="FINISAR CORP.",vendor_pn="FTLX8571D3BCL",vendor_rev="A",vendor_sn="ALA1234"} 1
+# HELP sff_rx_power_dbm Receive power per lane, not exported at 0 mW.
+# TYPE sff_rx_power_dbm gauge
+sff_rx_power_dbm{lane="1",source="DIR/qsfp.bin"} 0
+sff_rx_power_dbm{lane="2",source="DIR/qsfp.bin"} 0
+sff_rx_power_dbm{lane="3",source="DIR/qsfp.bin"} 0
+# HELP sff_rx_power_milliwatts Receive power per lane.
+# TYPE sff_rx_power_milliwatts gauge
+sff_rx_power_milliwatts{lane="1",source="DIR/qsfp.bin"} 1
+sff_rx_power_milliwatts{lane="1",source="DIR/sfp.bin"} 0
+sff_rx_power_milliwatts{lane="2",source="DIR/qsfp.bin"} 1
+sff_rx_power_milliwatts{lane="3",source="DIR/qsfp.bin"} 1
+sff_rx_power_milliwatts{lane="4",source="DIR/qsfp.bin"} 0
+# HELP sff_rx_power_threshold_dbm Receive power alarm and warning thresholds, not exported at 0 mW.
+# TYPE sff_rx_power_threshold_dbm gauge
+sff_rx_power_threshold_dbm{level="high_alarm",source="DIR/qsfp.bin"} 0
+# HELP sff_temperature_celsius Module temperature.
+# TYPE sff_temperature_celsius gauge
+sff_temperature_celsius{source="DIR/qsfp.bin"} 30
+sff_temperature_celsius{source="DIR/sfp.bin"} 33.5
+# HELP sff_temperature_threshold_celsius Temperature alarm and warning thresholds.
+# TYPE sff_temperature_threshold_celsius gauge
+sff_temperature_threshold_celsius{level="high_alarm",source="DIR/qsfp.bin"} 0
+sff_temperature_threshold_celsius{level="high_alarm",source="DIR/sfp.bin"} 80
+sff_temperature_threshold_celsius{level="high_warning",source="DIR/qsfp.bin"} 0
+sff_temperature_threshold_celsius{level="high_warning",source="DIR/sfp.bin"} 75
+sff_temperature_threshold_celsius{level="low_alarm",source="DIR/qsfp.bin"} 0
+sff_temperature_threshold_celsius{level="low_alarm",source="DIR/sfp.bin"} -10
+sff_temperature_threshold_celsius{level="low_warning",source="DIR/qsfp.bin"} 0
+sff_temperature_threshold_celsius{level="low_warning",source="DIR/sfp.bin"} -5
+# HELP sff_tx_bias_milliamperes Laser bias current per lane.
+# TYPE sff_tx_bias_milliamperes gauge
+sff_tx_bias_milliamperes{lane="1",source="DIR/qsfp.bin"} 12
+sff_tx_bias_milliamperes{lane="1",source="DIR/sfp.bin"} 12
+sff_tx_bias_milliamperes{lane="2",source="DIR/qsfp.bin"} 12
+sff_tx_bias_milliamperes{lane="3",source="DIR/qsfp.bin"} 12
+sff_tx_bias_milliamperes{lane="4",source="DIR/qsfp.bin"} 12
+# HELP sff_tx_power_dbm Transmit power per lane, not exported at 0 mW.
+# TYPE sff_tx_power_dbm gauge
+sff_tx_power_dbm{lane="1",source="DIR/qsfp.bin"} -3.010299956639812
+sff_tx_power_dbm{lane="1",source="DIR/sfp.bin"} -3.010299956639812
+sff_tx_power_dbm{lane="2",source="DIR/qsfp.bin"} -3.010299956639812
+sff_tx_power_dbm{lane="3",source="DIR/qsfp.bin"} -3.010299956639812
+sff_tx_power_dbm{lane="4",source="DIR/qsfp.bin"} -3.010299956639812
+# HELP sff_tx_power_milliwatts Transmit power per lane.
+# TYPE sff_tx_power_milliwatts gauge
+sff_tx_power_milliwatts{lane="1",source="DIR/qsfp.bin"} 0.5
+sff_tx_power_milliwatts{lane="1",source="DIR/sfp.bin"} 0.5
+sff_tx_power_milliwatts{lane="2",source="DIR/qsfp.bin"} 0.5
+sff_tx_power_milliwatts{lane="3",source="DIR/qsfp.bin"} 0.5
+sff_tx_power_milliwatts{lane="4",source="DIR/qsfp.bin"} 0.5
+# HELP sff_up Whether the module was read and decoded.
+# TYPE sff_up gauge
+sff_up{source="DIR/missing.bin"} 0
+sff_up{source="DIR/qsfp.bin"} 1
+sff_up{source="DIR/sfp.bin"} 1
+# HELP sff_voltage_volts Module supply voltage.
+# TYPE sff_voltage_volts gauge
+sff_voltage_volts{source="DIR/qsfp.bin"} 3.3
+sff_voltage_volts{source="DIR/sfp.bin"} 3.3
+`, "DIR", dir)
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want),
+		"sff_up", "sff_module_info", "sff_temperature_celsius", "sff_voltage_volts", "sff_tx_bias_milliamperes",
+		"sff_tx_power_milliwatts", "sff_tx_power_dbm", "sff_rx_power_milliwatts", "sff_rx_power_dbm",
+		"sff_temperature_threshold_celsius", "sff_rx_power_threshold_dbm"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,249 @@
+package sff
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/mickep76/go-sff/vendors"
+)
+
+//go:generate go run schema_gen.go
+
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaSamples returns eeproms covering optical and copper SFP and QSFP modules with and
+// without diagnostics or threshold pages. The copper samples have the bit rate in the
+// extended byte, the optical samples a known OUI and rate select rates, and the samples
+// without pages have an invalid date code. Non-zero values are used so keys such as dbm are
+// present and zero values so they aren't required, keys only present in some of the samples
+// aren't required.
+func schemaSamples() map[Type][][]byte {
+	fill := func(b []byte, v byte) {
+		for i := range b {
+			b[i] = v
+		}
+	}
+
+	sfp := func(copper bool) []byte {
+		b := make([]byte, 512)
+		b[0], b[1], b[2], b[92] = 0x03, 0x04, 0x07, 0x40
+		copy(b[84:], "200101  ")
+		if copper {
+			b[8], b[12], b[66] = 0x04, 0xFF, 0x67
+		} else {
+			b[13], b[14], b[15], b[16] = 0x06, 10, 0xFF, 10
+			copy(b[37:], []byte{0x00, 0x90, 0x65})
+			copy(b[90:], "01")
+		}
+		fill(b[256:384], 0x10)
+		return b
+	}
+
+	qsfp := func(copper bool) []byte {
+		b := make([]byte, 640)
+		fill(b[:128], 0x10)
+		b[0], b[128], b[130] = 0x11, 0x11, 0x07
+		copy(b[212:], "200101  ")
+		if copper {
+			b[140], b[147], b[222] = 0xFF, 0xA0, 0x67
+		} else {
+			b[141], b[142], b[143] = 0x02, 10, 0xFF
+			copy(b[165:], []byte{0x00, 0x90, 0x65})
+			copy(b[218:], "01")
+		}
+		fill(b[512:640], 0x10)
+		return b
+	}
+
+	noDiag := sfp(false)[:256]
+	noDiag[92] = 0x00
+	copy(noDiag[84:], "        ")
+
+	noThresholds := qsfp(false)[:256]
+	copy(noThresholds[212:], "        ")
+
+	sfpZero := sfp(true)
+	fill(sfpZero[256:384], 0x00)
+
+	qsfpZero := qsfp(true)
+	fill(qsfpZero[1:128], 0x00)
+	fill(qsfpZero[512:640], 0x00)
+
+	return map[Type][][]byte{
+		TypeSff8079: {sfp(false), sfp(true), noDiag, sfpZero},
+		TypeSff8636: {qsfp(false), qsfp(true), noThresholds, qsfpZero},
+	}
+}
+
+// vendorFieldsSchema returns the schema for vendorFields, it's only present when a registered
+// vendor decoder matches so it isn't in the samples.
+func vendorFieldsSchema() (map[string]interface{}, error) {
+	b, err := json.Marshal([]vendors.Field{{}})
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return inferSchema("", v), nil
+}
+
+// schemaOptional are the pages omitted from modules that aren't decoded from a full dump,
+// they're never required.
+var schemaOptional = map[string]bool{"diagnostics": true, "monitors": true, "thresholds": true}
+
+// inferSchema returns a schema for the generic JSON value.
+func inferSchema(key string, v interface{}) map[string]interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		props := map[string]interface{}{}
+		req := []string{}
+		for k, e := range t {
+			props[k] = inferSchema(k, e)
+			req = append(req, k)
+		}
+		sort.Strings(req)
+		return map[string]interface{}{"type": "object", "properties": props, "required": req, "additionalProperties": false}
+	case []interface{}:
+		s := map[string]interface{}{"type": "array"}
+		for _, e := range t {
+			s = mergeSchema(s, map[string]interface{}{"type": "array", "items": inferSchema("", e)})
+		}
+		return s
+	case string:
+		switch key {
+		case "hex":
+			return map[string]interface{}{"type": "string", "pattern": "^([0-9a-f]{2})*$"}
+		case "unit":
+			return map[string]interface{}{"type": "string", "enum": []string{t}}
+		}
+		return map[string]interface{}{"type": "string"}
+	case float64:
+		return map[string]interface{}{"type": "number"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	}
+	return map[string]interface{}{}
+}
+
+// mergeSchema returns a schema matching both a and b, properties are merged and only keys
+// required by both are required.
+func mergeSchema(a, b map[string]interface{}) map[string]interface{} {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	case a["type"] != b["type"]:
+		return map[string]interface{}{"anyOf": []interface{}{a, b}}
+	}
+
+	switch a["type"] {
+	case "object":
+		props := map[string]interface{}{}
+		for k, v := range a["properties"].(map[string]interface{}) {
+			props[k] = v
+		}
+		for k, v := range b["properties"].(map[string]interface{}) {
+			if p, ok := props[k]; ok {
+				props[k] = mergeSchema(p.(map[string]interface{}), v.(map[string]interface{}))
+			} else {
+				props[k] = v
+			}
+		}
+
+		inB := map[string]bool{}
+		for _, k := range b["required"].([]string) {
+			inB[k] = true
+		}
+		req := []string{}
+		for _, k := range a["required"].([]string) {
+			if inB[k] {
+				req = append(req, k)
+			}
+		}
+		return map[string]interface{}{"type": "object", "properties": props, "required": req, "additionalProperties": false}
+	case "array":
+		ia, _ := a["items"].(map[string]interface{})
+		ib, _ := b["items"].(map[string]interface{})
+		if s := mergeSchema(ia, ib); len(s) > 0 {
+			return map[string]interface{}{"type": "array", "items": s}
+		}
+	case "string":
+		ea, okA := a["enum"].([]string)
+		eb, okB := b["enum"].([]string)
+		if okA && okB {
+			return map[string]interface{}{"type": "string", "enum": union(ea, eb)}
+		}
+	}
+	return a
+}
+
+// union returns the sorted strings in a or b.
+func union(a, b []string) []string {
+	seen := map[string]bool{}
+	r := []string{}
+	for _, s := range append(append([]string{}, a...), b...) {
+		if !seen[s] {
+			seen[s] = true
+			r = append(r, s)
+		}
+	}
+	sort.Strings(r)
+	return r
+}
+
+// Schema returns the JSON Schema for the module encoding, or the values only encoding. It's
+// inferred from sample modules so it lists the keys, their types and the units, but not value
+// ranges or the names of enumerated values.
+func Schema(valuesOnly bool) ([]byte, error) {
+	types := []Type{TypeSff8079, TypeSff8636}
+	samples := schemaSamples()
+	vendorFields, err := vendorFieldsSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	l := []interface{}{}
+	for _, t := range types {
+		s := map[string]interface{}{}
+		for _, b := range samples[t] {
+			m, err := Decode(b)
+			if err != nil {
+				return nil, err
+			}
+
+			enc := NewEncoder(nil)
+			enc.SetValuesOnly(valuesOnly)
+			v, err := enc.generic(m)
+			if err != nil {
+				return nil, err
+			}
+			s = mergeSchema(s, inferSchema("", v))
+		}
+		req := []string{}
+		for _, k := range s["required"].([]string) {
+			if !schemaOptional[k] {
+				req = append(req, k)
+			}
+		}
+		s["required"] = req
+		props := s["properties"].(map[string]interface{})
+		props["type"] = map[string]interface{}{"const": string(t)}
+		props["vendorFields"] = vendorFields
+		s["title"] = string(t)
+		l = append(l, s)
+	}
+
+	title := "SFF module"
+	if valuesOnly {
+		title = "SFF module, values only"
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema": schemaDraft,
+		"title":   title,
+		"oneOf":   l,
+	}, "", "  ")
+}
@@ -0,0 +1,30 @@
+//go:build ignore
+// +build ignore
+
+// Generates the JSON Schemas in schema/ for the module and values only encodings.
+//
+//	go run schema_gen.go
+package main
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/mickep76/go-sff"
+)
+
+func main() {
+	for file, valuesOnly := range map[string]bool{
+		"schema/module.schema.json":        false,
+		"schema/module-values.schema.json": true,
+	} {
+		b, err := sff.Schema(valuesOnly)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(file, append(b, '\n'), 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package sff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"testing"
+
+	_ "github.com/mickep76/go-sff/vendors/arista"
+)
+
+var schemaFiles = map[string]bool{
+	"schema/module.schema.json":        false,
+	"schema/module-values.schema.json": true,
+}
+
+func TestSchemaFiles(t *testing.T) {
+	for file, valuesOnly := range schemaFiles {
+		b, err := Schema(valuesOnly)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(append(b, '\n'), f) {
+			t.Errorf("%s: out of date, run go generate", file)
+		}
+	}
+}
+
+// validate returns the errors for v against the subset of JSON Schema used by Schema.
+func validate(s map[string]interface{}, v interface{}, path string) []string {
+	if c, ok := s["const"]; ok && c != v {
+		return []string{fmt.Sprintf("%s: got %v, want %v", path, v, c)}
+	}
+	for _, k := range []string{"oneOf", "anyOf"} {
+		l, ok := s[k].([]interface{})
+		if !ok {
+			continue
+		}
+		n, errs := 0, []string{}
+		for _, e := range l {
+			r := validate(e.(map[string]interface{}), v, path)
+			if len(r) == 0 {
+				n++
+			} else if len(errs) == 0 || len(r) < len(errs) {
+				errs = r
+			}
+		}
+		if (k == "oneOf" && n != 1) || n == 0 {
+			return append([]string{fmt.Sprintf("%s: matches %d of %s", path, n, k)}, errs...)
+		}
+		return nil
+	}
+
+	if e, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, x := range e {
+			found = found || x == v
+		}
+		if !found {
+			return []string{fmt.Sprintf("%s: %v not in %v", path, v, e)}
+		}
+	}
+
+	errs := []string{}
+	switch s["type"] {
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want object", path, v)}
+		}
+		for _, k := range s["required"].([]interface{}) {
+			if _, ok := m[k.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.%s: missing", path, k))
+			}
+		}
+		props := s["properties"].(map[string]interface{})
+		keys := []string{}
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := props[k]; ok {
+				errs = append(errs, validate(p.(map[string]interface{}), m[k], path+"."+k)...)
+			} else if s["additionalProperties"] == false {
+				errs = append(errs, fmt.Sprintf("%s.%s: not in schema", path, k))
+			}
+		}
+	case "array":
+		l, ok := v.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want array", path, v)}
+		}
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, e := range l {
+				errs = append(errs, validate(items, e, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s: got %T, want string", path, v)}
+		}
+		if p, ok := s["pattern"].(string); ok && !regexp.MustCompile(p).MatchString(str) {
+			errs = append(errs, fmt.Sprintf("%s: %q doesn't match %s", path, str, p))
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return []string{fmt.Sprintf("%s: got %T, want number", path, v)}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []string{fmt.Sprintf("%s: got %T, want boolean", path, v)}
+		}
+	}
+	return errs
+}
+
+func TestSchemaValidate(t *testing.T) {
+	arista := sfpDump()
+	copy(arista[20:36], "Arista Networks ")
+	copy(arista[40:56], "CAB-Q-S-100G-1M ")
+	arista[120] = 0xab
+
+	dumps := map[string][]byte{"sfp": sfpDump(), "sfp A0h": sfpDump()[:256], "qsfp": qsfpDump(), "qsfp page 00h": qsfpDump()[:256], "arista": arista}
+	for t, l := range schemaSamples() {
+		for i, b := range l {
+			dumps[fmt.Sprintf("%s sample %d", t, i)] = b
+		}
+	}
+
+	for file, valuesOnly := range schemaFiles {
+		f, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := map[string]interface{}{}
+		if err := json.Unmarshal(f, &s); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, b := range dumps {
+			m := decode(t, b)
+			if name == "arista" && len(m.Sff8079.VendorFields()) == 0 {
+				t.Fatalf("%s: no vendor fields", name)
+			}
+
+			buf := &bytes.Buffer{}
+			enc := NewEncoder(buf)
+			enc.SetValuesOnly(valuesOnly)
+			if err := enc.Encode(m); err != nil {
+				t.Fatal(err)
+			}
+
+			var v interface{}
+			if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range validate(s, v, "") {
+				t.Errorf("%s: %s: %s", file, name, e)
+			}
+		}
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/mickep76/go-sff/common"
 	"github.com/mickep76/go-sff/sff8079"
+	"github.com/mickep76/go-sff/sff8472"
 	"github.com/mickep76/go-sff/sff8636"
+	"gopkg.in/yaml.v3"
 )
 
 // Type of eeprom module.
@@ -21,29 +24,48 @@ const (
 var ErrUnknownType = errors.New("unknown type")
 
 type Module struct {
-	Type             Type `json:"type"`
-	*sff8079.Sff8079 `json:"-"`
-	*sff8636.Sff8636 `json:"-"`
+	Type                Type `json:"type"`
+	*sff8079.Sff8079    `json:"-"`
+	*sff8472.Sff8472    `json:"-"`
+	*sff8636.Sff8636    `json:"-"`
+	*sff8636.LowerPage  `json:"-"`
+	*sff8636.Thresholds `json:"-"`
 }
 
-type module Module
+type module struct {
+	Type Type `json:"type"`
+}
 
 type moduleSff8079 struct {
 	Type Type `json:"type"`
 	*sff8079.Sff8079
+	Diagnostics *sff8472.Sff8472 `json:"diagnostics,omitempty"`
 }
 
 type moduleSff8636 struct {
 	Type Type `json:"type"`
 	*sff8636.Sff8636
+	Monitors   *sff8636.LowerPage  `json:"monitors,omitempty"`
+	Thresholds *sff8636.Thresholds `json:"thresholds,omitempty"`
 }
 
 func (m *Module) String() string {
 	switch m.Type {
 	case TypeSff8079:
-		return m.Sff8079.String()
+		str := m.Sff8079.String()
+		if d := m.Diagnostics(); d != nil {
+			str += d.String()
+		}
+		return str
 	case TypeSff8636:
-		return m.Sff8636.String()
+		str := m.Sff8636.String() + fmt.Sprintf("%-50s : %s\n", m.maxPowerKey(), m.Sff8636.MaxPowerW(m.LowerPage))
+		if m.LowerPage != nil {
+			str += m.LowerPage.String()
+		}
+		if m.Thresholds != nil {
+			str += m.Thresholds.String()
+		}
+		return str
 	}
 	return ""
 }
@@ -51,19 +73,124 @@ func (m *Module) String() string {
 func (m *Module) StringCol() string {
 	switch m.Type {
 	case TypeSff8079:
-		return m.Sff8079.StringCol()
+		str := m.Sff8079.StringCol()
+		if d := m.Diagnostics(); d != nil {
+			str += d.StringCol()
+		}
+		return str
 	case TypeSff8636:
-		return m.Sff8636.StringCol()
+		str := m.Sff8636.StringCol() + fmt.Sprintf("%s%-50s%s : %s%s%s\n", cyan, m.maxPowerKey(), clear, green, m.Sff8636.MaxPowerW(m.LowerPage), clear)
+		if m.LowerPage != nil {
+			str += m.LowerPage.StringCol()
+		}
+		if m.Thresholds != nil {
+			str += m.Thresholds.StringCol()
+		}
+		return str
 	}
 	return ""
 }
 
+func (m *Module) maxPowerKey() string {
+	if m.Sff8636.PowerClass() == 8 {
+		return "Max Power Consumption [107]"
+	}
+	return "Max Power Consumption [129]"
+}
+
+// Diagnostics returns the SFF-8472 diagnostics with the external calibration applied for
+// externally calibrated modules, nil if the module has no diagnostics. The Sff8472 field
+// always holds the raw bytes.
+func (m *Module) Diagnostics() *sff8472.Sff8472 {
+	if m.Type != TypeSff8079 || m.Sff8472 == nil {
+		return nil
+	}
+	if m.externalCal() {
+		return m.Sff8472.Calibrate()
+	}
+	return m.Sff8472
+}
+
+func (m *Module) externalCal() bool {
+	return m.Sff8079 != nil && m.Sff8472 != nil && m.Sff8079.DiagMonitType.Has(sff8079.DiagExternalCal)
+}
+
+// Lint returns the issues found in the module fields.
+func (m *Module) Lint() []common.Issue {
+	switch m.Type {
+	case TypeSff8079:
+		return m.Sff8079.Lint()
+	case TypeSff8636:
+		return m.Sff8636.Lint()
+	}
+	return []common.Issue{}
+}
+
+// Alarms returns the active alarm flags, empty if the module has no diagnostics.
+func (m *Module) Alarms() []string {
+	switch {
+	case m.Type == TypeSff8079 && m.Sff8472 != nil:
+		return m.Sff8472.AlarmFlags.List()
+	case m.Type == TypeSff8636 && m.LowerPage != nil:
+		return m.LowerPage.Alarms()
+	}
+	return []string{}
+}
+
+// Warnings returns the active warning flags, empty if the module has no diagnostics.
+func (m *Module) Warnings() []string {
+	switch {
+	case m.Type == TypeSff8079 && m.Sff8472 != nil:
+		return m.Sff8472.WarningFlags.List()
+	case m.Type == TypeSff8636 && m.LowerPage != nil:
+		return m.LowerPage.Warnings()
+	}
+	return []string{}
+}
+
+// joinJSON marshals each value and joins the resulting JSON objects.
+func joinJSON(v ...interface{}) ([]byte, error) {
+	r := []byte{'{'}
+	for _, o := range v {
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) < 2 || b[0] != '{' {
+			return nil, fmt.Errorf("can't join JSON: %s", string(b))
+		}
+		if len(b) == 2 {
+			continue
+		}
+		if len(r) > 1 {
+			r = append(r, ',')
+		}
+		r = append(r, b[1:len(b)-1]...)
+	}
+	return append(r, '}'), nil
+}
+
 func (m *Module) MarshalJSON() ([]byte, error) {
 	switch m.Type {
 	case TypeSff8079:
-		return json.Marshal(moduleSff8079{Type: m.Type, Sff8079: m.Sff8079})
+		var d interface{}
+		switch {
+		case m.externalCal():
+			d = sff8472.Calibrated{Sff8472: m.Sff8472}
+		case m.Sff8472 != nil:
+			d = m.Sff8472
+		}
+		return joinJSON(module{Type: m.Type}, m.Sff8079, struct {
+			Diagnostics interface{} `json:"diagnostics,omitempty"`
+		}{d})
 	case TypeSff8636:
-		return json.Marshal(moduleSff8636{Type: m.Type, Sff8636: m.Sff8636})
+		// The raw byte 107 is kept in monitors, maxPower is the max power for the power class.
+		return joinJSON(module{Type: m.Type}, m.Sff8636, struct {
+			MaxPower   common.Watts        `json:"maxPower"`
+			Monitors   *sff8636.LowerPage  `json:"monitors,omitempty"`
+			Thresholds *sff8636.Thresholds `json:"thresholds,omitempty"`
+		}{m.Sff8636.MaxPowerW(m.LowerPage), m.LowerPage, m.Thresholds})
 	}
 	return nil, ErrUnknownType
 }
@@ -78,23 +205,34 @@ func (m *Module) UnmarshalJSON(in []byte) error {
 
 	switch mod.Type {
 	case TypeSff8079:
-		s := &sff8079.Sff8079{}
+		s := &moduleSff8079{Sff8079: &sff8079.Sff8079{}}
 		if err := json.Unmarshal(in, s); err != nil {
 			return err
 		}
-		m.Sff8079 = s
+		m.Sff8079 = s.Sff8079
+		m.Sff8472 = s.Diagnostics
 		return nil
 	case TypeSff8636:
-		s := &sff8636.Sff8636{}
+		s := &moduleSff8636{Sff8636: &sff8636.Sff8636{}}
 		if err := json.Unmarshal(in, s); err != nil {
 			return err
 		}
-		m.Sff8636 = s
+		m.Sff8636 = s.Sff8636
+		m.LowerPage = s.Monitors
+		m.Thresholds = s.Thresholds
 		return nil
 	}
 	return ErrUnknownType
 }
 
+func (m *Module) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(m)
+}
+
+func (m *Module) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, m)
+}
+
 func GetType(eeprom []byte) (Type, error) {
 	if len(eeprom) < 256 {
 		return TypeUnknown, fmt.Errorf("eeprom size to small needs to be 256 bytes or larger got: %d bytes", len(eeprom))
@@ -123,13 +261,30 @@ func Decode(eeprom []byte) (*Module, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &Module{Type: TypeSff8079, Sff8079: m}, nil
+
+		mod := &Module{Type: TypeSff8079, Sff8079: m}
+		if len(eeprom) >= 512 && m.HasDiagnostics() {
+			if mod.Sff8472, err = sff8472.Decode(eeprom[256:]); err != nil {
+				return nil, err
+			}
+		}
+		return mod, nil
 	case TypeSff8636:
 		m, err := sff8636.Decode(eeprom)
 		if err != nil {
 			return nil, err
 		}
-		return &Module{Type: TypeSff8636, Sff8636: m}, nil
+
+		mod := &Module{Type: TypeSff8636, Sff8636: m}
+		if mod.LowerPage, err = sff8636.DecodeLowerPage(eeprom); err != nil {
+			return nil, err
+		}
+		if len(eeprom) >= 640 {
+			if mod.Thresholds, err = sff8636.DecodeThresholds(eeprom[512:]); err != nil {
+				return nil, err
+			}
+		}
+		return mod, nil
 	}
 	return nil, ErrUnknownType
 }
@@ -0,0 +1,26 @@
+package sff8079
+
+import (
+	"unsafe"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+func (s *Sff8079) bytes() []byte {
+	return (*[256]byte)(unsafe.Pointer(s))[:]
+}
+
+// Bytes returns a copy of the page bytes.
+func (s *Sff8079) Bytes() []byte {
+	return append([]byte{}, s.bytes()...)
+}
+
+// ChecksumBase returns the calculated checksum for bytes 0-62, compare with CcBase.
+func (s *Sff8079) ChecksumBase() common.Checksum {
+	return common.CalculateChecksum(s.bytes()[0:63])
+}
+
+// ChecksumExt returns the calculated checksum for bytes 64-94, compare with CcExt.
+func (s *Sff8079) ChecksumExt() common.Checksum {
+	return common.CalculateChecksum(s.bytes()[64:95])
+}
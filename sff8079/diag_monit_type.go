@@ -0,0 +1,140 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	DiagAddrChange   = (1 << 2)
+	DiagRxPwrAverage = (1 << 3)
+	DiagExternalCal  = (1 << 4)
+	DiagInternalCal  = (1 << 5)
+	DiagImplemented  = (1 << 6)
+	DiagLegacy       = (1 << 7)
+)
+
+var diagMonitTypeNames = map[byte]string{
+	DiagAddrChange:   "Address change required",
+	DiagRxPwrAverage: "Received power measurement type: Average power",
+	DiagExternalCal:  "Externally calibrated",
+	DiagInternalCal:  "Internally calibrated",
+	DiagImplemented:  "Digital diagnostic monitoring implemented",
+	DiagLegacy:       "Reserved for legacy diagnostic implementations",
+}
+
+// DiagMonitType is the diagnostic monitoring type, byte 92.
+type DiagMonitType byte
+
+// Has returns true if all bits are set, see DiagImplemented etc.
+func (d DiagMonitType) Has(opt byte) bool {
+	return byte(d)&opt == opt
+}
+
+func (d DiagMonitType) List() []string {
+	r := []string{}
+	for i := 7; i >= 2; i-- {
+		if d.Has(1 << uint(i)) {
+			r = append(r, diagMonitTypeNames[1<<uint(i)])
+		} else if 1<<uint(i) == DiagRxPwrAverage && d.Has(DiagImplemented) {
+			r = append(r, "Received power measurement type: OMA")
+		}
+	}
+	return r
+}
+
+func (d DiagMonitType) String() string {
+	return strings.Join(d.List(), "\n")
+}
+
+func (d DiagMonitType) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": d.List(),
+		"hex":    hex.EncodeToString([]byte{byte(d)}),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DiagMonitType) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*d = DiagMonitType(b[0])
+	return nil
+}
+
+const (
+	Sff8472CompUndefined = 0x00
+	Sff8472CompRev9_3    = 0x01
+	Sff8472CompRev9_5    = 0x02
+	Sff8472CompRev10_2   = 0x03
+	Sff8472CompRev10_4   = 0x04
+	Sff8472CompRev11_0   = 0x05
+	Sff8472CompRev11_3   = 0x06
+	Sff8472CompRev11_4   = 0x07
+	Sff8472CompRev12_3   = 0x08
+	Sff8472CompRev12_4   = 0x09
+)
+
+var sff8472CompNames = map[byte]string{
+	Sff8472CompUndefined: "Digital diagnostic functionality not included or undefined",
+	Sff8472CompRev9_3:    "SFF-8472 Rev 9.3",
+	Sff8472CompRev9_5:    "SFF-8472 Rev 9.5",
+	Sff8472CompRev10_2:   "SFF-8472 Rev 10.2",
+	Sff8472CompRev10_4:   "SFF-8472 Rev 10.4",
+	Sff8472CompRev11_0:   "SFF-8472 Rev 11.0",
+	Sff8472CompRev11_3:   "SFF-8472 Rev 11.3",
+	Sff8472CompRev11_4:   "SFF-8472 Rev 11.4",
+	Sff8472CompRev12_3:   "SFF-8472 Rev 12.3",
+	Sff8472CompRev12_4:   "SFF-8472 Rev 12.4",
+}
+
+// Sff8472Comp is the SFF-8472 revision the module complies with, byte 94.
+type Sff8472Comp byte
+
+func (s Sff8472Comp) String() string {
+	n, ok := sff8472CompNames[byte(s)]
+	if !ok {
+		return "Reserved or unknown"
+	}
+	return n
+}
+
+func (s Sff8472Comp) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": s.String(),
+		"hex":   hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *Sff8472Comp) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*s = Sff8472Comp(b[0])
+	return nil
+}
+
+// HasDiagnostics returns true if the diagnostic page at A2h is implemented and
+// accessible without an address change sequence.
+func (s *Sff8079) HasDiagnostics() bool {
+	return s.DiagMonitType.Has(DiagImplemented) && !s.DiagMonitType.Has(DiagAddrChange)
+}
@@ -0,0 +1,53 @@
+package sff8079
+
+import (
+	"github.com/mickep76/go-sff/common"
+)
+
+// Om4 returns true if byte 18 is an OM4 length, it's a copper length for copper modules
+// and for modules complying with SFF-8472 revisions before 11.0, which added OM4.
+func (s *Sff8079) Om4() bool {
+	if s.Copper() {
+		return false
+	}
+	return s.Sff8472Comp == Sff8472CompUndefined || s.Sff8472Comp >= Sff8472CompRev11_0
+}
+
+// LengthOm4OrCopper returns byte 18 as an OM4 length in units of 10 m or a copper
+// cable length in units of 1 m.
+func (s *Sff8079) LengthOm4OrCopper() common.Length {
+	if s.Om4() {
+		return common.Value10m(s.LengthCopper)
+	}
+	return s.LengthCopper
+}
+
+func (s *Sff8079) lengthOm4OrCopperKey() string {
+	if s.Om4() {
+		return "Length (OM4) [18]"
+	}
+	return "Length (Copper) [18]"
+}
+
+// Reach returns the supported link lengths per medium, SMF uses byte 15 unless it's
+// saturated or zero.
+func (s *Sff8079) Reach() common.Reaches {
+	r := common.Reaches{}
+	if s.LengthSmfM != 0 && s.LengthSmfM != 0xFF {
+		r = r.Add(common.MediumSmf, s.LengthSmfM)
+	} else {
+		r = r.Add(common.MediumSmf, s.LengthSmfKm)
+	}
+
+	if s.Copper() {
+		return r.Add(common.MediumCopper, s.LengthCopper)
+	}
+
+	r = r.Add(common.MediumOm1, s.Length625umM).
+		Add(common.MediumOm2, s.Length50umM).
+		Add(common.MediumOm3, s.LengthOm3)
+	if s.Om4() {
+		r = r.Add(common.MediumOm4, common.Value10m(s.LengthCopper))
+	}
+	return r
+}
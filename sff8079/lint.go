@@ -0,0 +1,13 @@
+package sff8079
+
+import (
+	"github.com/mickep76/go-sff/common"
+)
+
+// Lint returns the issues found in the ASCII fields.
+func (s *Sff8079) Lint() []common.Issue {
+	r := common.LintASCII("Vendor", 20, s.Vendor[:])
+	r = append(r, common.LintASCII("VendorPn", 40, s.VendorPn[:])...)
+	r = append(r, common.LintASCII("VendorRev", 56, s.VendorRev[:])...)
+	return append(r, common.LintASCII("VendorSn", 68, s.VendorSn[:])...)
+}
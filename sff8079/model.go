@@ -1,11 +1,14 @@
 package sff8079
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"unsafe"
 
-	"gitlab.trading.imc.intra/go/go-sff/common"
+	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/vendors"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,40 +23,38 @@ const (
 )
 
 type Sff8079 struct {
-	Identifier      common.Identifier   `json:"identifier"`     // 0 - Identifier
-	ExtIdentifier   ExtIdentifier       `json:"extIdentifier"`  // 1 - Ext. Identifier
-	Connector       common.Connector    `json:"connector"`      // 2 - Connector
-	Transceiver     Transceiver         `json:"transceiver"`    // 3-10 - Transceiver
-	Encoding        Encoding            `json:"encoding"`       // 11 - Encoding
-	BrNominal       common.Value100Mbps `json:"brNominal"`      // 12 - BR Nominal
-	RateIdentifier  byte                `json:"rateIdentifier"` // 13 - Rate ID
-	LengthSmfKm     common.ValueKm      `json:"lengthSmfKm"`    // 14 - Length(9μm) - km - (SMF)?
-	LengthSmfM      common.ValueM       `json:"lengthSmfM"`     // 15 - Length (9μm) - (SMF)?
-	Length50umM     common.ValueM       `json:"length50umM"`    // 16 - Length (50μm)
-	Length625umM    common.ValueM       `json:"length625umM"`   // 17 - Length (62.5um)
-	LengthCopper    common.ValueM       `json:"lengthCopper"`   // 18 - Length (Copper)
-	LengthOm3       common.ValueM       `json:"lengthOm3"`      // 19 - Length (50μm)
-	Vendor          common.String16     `json:"vendor"`         // 20-35 - Vendor name
-	TranscComp      byte                `json:"-"`              // 36 - Transciever
-	VendorOui       common.VendorOUI    `json:"vendorOui"`      // 37-39 - Vendor OUI
-	VendorPn        common.String16     `json:"vendorPn"`       // 40-55 - Vendor PN
-	VendorRev       common.String4      `json:"vendorRev"`      // 56-59 - Vendor rev
-	LaserWavelength [2]byte             `json:"-"`              // 60-61 - Laser wavelength
-	Unallocated     byte                `json:"-"`              // 62 - Unallocated
-	CcBase          byte                `json:"-"`              // 63 - CC_BASE
-	Options         [2]byte             `json:"options"`        // 64-65 - Options
-	BrMax           common.ValuePerc    `json:"brMax"`          // 66 - BR, max
-	BrMin           common.ValuePerc    `json:"brMin"`          // 67 - BR, min
-	VendorSn        common.String16     `json:"vendorSn"`       // 68-83 - Vendor SN
-	DateCode        common.DateCode     `json:"dateCode"`       // 84-91 - Date code
-	DiagMonitType   byte                `json:"-"`              // 92 - Diagnostic Monitoring Type
-	EnhancedOpts    byte                `json:"-"`              // 93 - Enhanced Options
-	Sff8472Comp     byte                `json:"-"`              // 94 - SFF-8472 Compliance
-	CcExt           byte                `json:"-"`              // 95 - CC_EXT
-	VendorSpec1     [24]byte            `json:"-"`              // 96-119 - Vendor Specific 1
-	VendorAristaSa  byte                `json:"vendorSa"`       // 120 Vendor Arista SA
-	VendorSpec2     [7]byte             `json:"-"`              // 121-127 - Vendor Specific 2
-	Reserved        [128]byte           `json:"-"`              // 128-255 - Reserved
+	Identifier      common.Identifier    `json:"identifier"`      // 0 - Identifier
+	ExtIdentifier   ExtIdentifier        `json:"extIdentifier"`   // 1 - Ext. Identifier
+	Connector       common.Connector     `json:"connector"`       // 2 - Connector
+	Transceiver     Transceiver          `json:"transceiver"`     // 3-10 - Transceiver
+	Encoding        Encoding             `json:"encoding"`        // 11 - Encoding
+	BrNominal       common.Value100Mbps  `json:"brNominal"`       // 12 - BR Nominal
+	RateIdentifier  RateIdentifier       `json:"rateIdentifier"`  // 13 - Rate ID
+	LengthSmfKm     common.ValueKm       `json:"lengthSmfKm"`     // 14 - Length(9μm) - km - (SMF)?
+	LengthSmfM      common.Value100m     `json:"lengthSmfM"`      // 15 - Length (9μm) - (SMF)?
+	Length50umM     common.Value10m      `json:"length50umM"`     // 16 - Length (50μm)
+	Length625umM    common.Value10m      `json:"length625umM"`    // 17 - Length (62.5um)
+	LengthCopper    common.ValueM        `json:"lengthCopper"`    // 18 - Length (OM4 or Copper)
+	LengthOm3       common.Value10m      `json:"lengthOm3"`       // 19 - Length (50μm)
+	Vendor          common.String16      `json:"vendor"`          // 20-35 - Vendor name
+	TranscComp      common.ExtCompliance `json:"transcComp"`      // 36 - Extended Specification Compliance Codes
+	VendorOui       common.VendorOUI     `json:"vendorOui"`       // 37-39 - Vendor OUI
+	VendorPn        common.String16      `json:"vendorPn"`        // 40-55 - Vendor PN
+	VendorRev       common.String4       `json:"vendorRev"`       // 56-59 - Vendor rev
+	LaserWavelength Wavelength           `json:"laserWavelength"` // 60-61 - Laser wavelength or Cable Compliance
+	Unallocated     byte                 `json:"-"`               // 62 - Unallocated
+	CcBase          common.Checksum      `json:"ccBase"`          // 63 - CC_BASE
+	Options         Options              `json:"options"`         // 64-65 - Options
+	BrMax           common.ValuePerc     `json:"brMax"`           // 66 - BR, max
+	BrMin           common.ValuePerc     `json:"brMin"`           // 67 - BR, min
+	VendorSn        common.String16      `json:"vendorSn"`        // 68-83 - Vendor SN
+	DateCode        common.DateCode      `json:"dateCode"`        // 84-91 - Date code
+	DiagMonitType   DiagMonitType        `json:"diagMonitType"`   // 92 - Diagnostic Monitoring Type
+	EnhancedOpts    EnhancedOpts         `json:"enhancedOpts"`    // 93 - Enhanced Options
+	Sff8472Comp     Sff8472Comp          `json:"sff8472Comp"`     // 94 - SFF-8472 Compliance
+	CcExt           common.Checksum      `json:"ccExt"`           // 95 - CC_EXT
+	VendorSpec      common.VendorSpec    `json:"vendorSpec"`      // 96-127 - Vendor Specific
+	Reserved        [128]byte            `json:"-"`               // 128-255 - Reserved
 }
 
 func Decode(eeprom []byte) (*Sff8079, error) {
@@ -69,33 +70,103 @@ func Decode(eeprom []byte) (*Sff8079, error) {
 	return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", byte(eeprom[0]))
 }
 
+type sff8079 Sff8079
+
+func (s *Sff8079) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		sff8079
+		BrNominal       interface{}     `json:"brNominal"`
+		LaserWavelength interface{}     `json:"laserWavelength"`
+		LengthCopper    common.Length   `json:"lengthCopper"`
+		BrNominalMbps   uint            `json:"brNominalMbps"`
+		Pmd             string          `json:"pmd,omitempty"`
+		Reach           common.Reaches  `json:"reach"`
+		RateSelect      []string        `json:"rateSelectControl"`
+		VendorName      string          `json:"vendorName"`
+		VendorFields    []vendors.Field `json:"vendorFields,omitempty"`
+	}{
+		sff8079:         sff8079(*s),
+		BrNominal:       s.brNominalJSON(),
+		LaserWavelength: s.wavelengthJSON(),
+		LengthCopper:    s.LengthOm4OrCopper(),
+		BrNominalMbps:   s.BrNominalMbps(),
+		Pmd:             s.Pmd(),
+		Reach:           s.Reach(),
+		RateSelect:      s.RateSelectControl(),
+		VendorName:      s.VendorName(),
+		VendorFields:    s.VendorFields(),
+	})
+}
+
+func (s *Sff8079) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(s)
+}
+
+func (s *Sff8079) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, s)
+}
+
+// brNominalJSON returns byte 12 without a value when the bit rate is in byte 66, see
+// BrNominalMbps.
+func (s *Sff8079) brNominalJSON() interface{} {
+	if s.BrNominal == 0xFF && s.BrMax != 0 {
+		return map[string]string{"hex": "ff"}
+	}
+	return s.BrNominal
+}
+
+func (s *Sff8079) brNominalKey() string {
+	if s.BrNominal == 0xFF && s.BrMax != 0 {
+		return "BR, Nominal [66]"
+	}
+	return "BR, Nominal [12]"
+}
+
 func (s *Sff8079) String() string {
 	str := fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Identifier [0]", byte(s.Identifier), s.Identifier) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Extended Identifier [1]", byte(s.ExtIdentifier), s.ExtIdentifier) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Connector [2]", byte(s.Connector), s.Connector) +
 		fmt.Sprintf("%-50s : 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x\n", "Transceiver Codes [3-10]", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]) +
-		fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.Transceiver.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Extended Compliance [36]", byte(s.TranscComp), s.TranscComp) +
+		fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.TransceiverList(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "PMD (best effort)", s.Pmd()) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Encoding [11]", byte(s.Encoding), s.Encoding) +
-		fmt.Sprintf("%-50s : %s\n", "BR, Nominal [12]", s.BrNominal) +
-		fmt.Sprintf("%-50s : 0x%02x\n", "Rate Identifier [13]", s.RateIdentifier) +
+		fmt.Sprintf("%-50s : %d Mb/s\n", s.brNominalKey(), s.BrNominalMbps()) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Rate Identifier [13]", byte(s.RateIdentifier), s.RateIdentifier) +
+		fmt.Sprintf("%-50s : %s\n", "Rate Select Rates", strings.Join(s.RateIdentifier.Rates(), ", ")) +
+		fmt.Sprintf("%-50s : %s\n", "Rate Select Control", strings.Join(s.RateSelectControl(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "Length (SMF) [14]", s.LengthSmfKm.String()) +
 		fmt.Sprintf("%-50s : %s\n", "Length (SMF) [15]", s.LengthSmfM) +
 		fmt.Sprintf("%-50s : %s\n", "Length (50um) [16]", s.Length50umM) +
 		fmt.Sprintf("%-50s : %s\n", "Length (62.5um) [17]", s.Length625umM) +
-		fmt.Sprintf("%-50s : %s\n", "Length (Copper) [18]", s.LengthCopper) +
+		fmt.Sprintf("%-50s : %s\n", s.lengthOm4OrCopperKey(), s.LengthOm4OrCopper()) +
 		fmt.Sprintf("%-50s : %s\n", "Length (OM3) [19]", s.LengthOm3) +
+		fmt.Sprintf("%-50s : %s\n", "Reach", strings.Join(s.Reach().List(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor [20-35]", s.Vendor) +
-		fmt.Sprintf("%-50s : %s\n", "Vendor OUI [37-39]", s.VendorOui) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor Name", s.VendorName()) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor OUI [37-39]", ouiString(s.VendorOui)) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor PN [40-55]", s.VendorPn) +
-		fmt.Sprintf("%-50s : %s\n", "Vendor Rev [56-59]", s.VendorRev) +
-		fmt.Sprintf("%-50s : 0x%02x 0x%02x\n", "Option Values [64-65]", s.Options[0], s.Options[1]) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor Rev [56-59]", s.VendorRev)
+
+	if s.CopperCable() {
+		str += fmt.Sprintf("%-50s : %s\n", "Cable Compliance [60-61]", strings.Join(s.CableCompliance(), fmt.Sprintf("\n%-50s : ", " ")))
+	} else {
+		str += fmt.Sprintf("%-50s : %s\n", "Laser Wavelength [60-61]", s.LaserWavelength)
+	}
+
+	str += fmt.Sprintf("%-50s : 0x%02x 0x%02x\n", "Option Values [64-65]", s.Options[0], s.Options[1]) +
+		fmt.Sprintf("%-50s : %s\n", "Options [64-65]", strings.Join(s.Options.List(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "BR Margin, Max [66]", s.BrMax) +
 		fmt.Sprintf("%-50s : %s\n", "BR Margin, Min [67]", s.BrMin) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor SN [68-83]", s.VendorSn) +
-		fmt.Sprintf("%-50s : %s\n", "Date Code [84-91]", s.DateCode)
-
-	if s.Vendor.String() == "Arista Networks" && strings.HasPrefix(s.VendorPn.String(), "CAB-Q-S-") {
-		str += fmt.Sprintf("%-50s : %x\n", "Vendor SA [120]", s.VendorAristaSa)
+		fmt.Sprintf("%-50s : %s\n", "Date Code [84-91]", s.DateCode) +
+		fmt.Sprintf("%-50s : %s\n", "Lot Code [90-91]", s.DateCode.Lot()) +
+		fmt.Sprintf("%-50s : %s\n", "Diagnostic Monitoring Type [92]", strings.Join(s.DiagMonitType.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Enhanced Options [93]", strings.Join(s.EnhancedOpts.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "SFF-8472 Compliance [94]", byte(s.Sff8472Comp), s.Sff8472Comp)
+
+	for _, f := range s.VendorFields() {
+		str += fmt.Sprintf("%-50s : %s\n", f.Key(), f.Value)
 	}
 
 	return str
@@ -122,28 +193,46 @@ func (s *Sff8079) StringCol() string {
 		strCol("Extended Identifier [1]", fmt.Sprintf("0x%02x (%s)", byte(s.ExtIdentifier), s.ExtIdentifier), cyan, green) +
 		strCol("Connector [2]", fmt.Sprintf("0x%02x (%s)", byte(s.Connector), s.Connector), cyan, green) +
 		strCol("Transceiver Codes [3-10]", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]), cyan, green) +
-		joinStrCol("Transceiver Type", s.Transceiver.List(), cyan, yellow) +
+		strCol("Extended Compliance [36]", fmt.Sprintf("0x%02x (%s)", byte(s.TranscComp), s.TranscComp), cyan, green) +
+		joinStrCol("Transceiver Type", s.TransceiverList(), cyan, yellow) +
+		strCol("PMD (best effort)", s.Pmd(), cyan, yellow) +
 		strCol("Encoding [11]", fmt.Sprintf("0x%02x (%s)", byte(s.Encoding), s.Encoding), cyan, green) +
-		strCol("BR, Nominal [12]", s.BrNominal.String(), cyan, green) +
-		strCol("Rate Identifier [13]", fmt.Sprintf("0x%02x", s.RateIdentifier), cyan, green) +
+		strCol(s.brNominalKey(), fmt.Sprintf("%d Mb/s", s.BrNominalMbps()), cyan, green) +
+		strCol("Rate Identifier [13]", fmt.Sprintf("0x%02x (%s)", byte(s.RateIdentifier), s.RateIdentifier), cyan, green) +
+		strCol("Rate Select Rates", strings.Join(s.RateIdentifier.Rates(), ", "), cyan, green) +
+		joinStrCol("Rate Select Control", s.RateSelectControl(), cyan, yellow) +
 		strCol("Length (SMF) [14]", s.LengthSmfKm.String(), cyan, green) +
 		strCol("Length (SMF) [15]", s.LengthSmfM.String(), cyan, green) +
 		strCol("Length (50um) [16]", s.Length50umM.String(), cyan, green) +
 		strCol("Length (62.5um) [17]", s.Length625umM.String(), cyan, green) +
-		strCol("Length (Copper) [18]", s.LengthCopper.String(), cyan, green) +
+		strCol(s.lengthOm4OrCopperKey(), s.LengthOm4OrCopper().String(), cyan, green) +
 		strCol("Length (OM3) [19]", s.LengthOm3.String(), cyan, green) +
+		joinStrCol("Reach", s.Reach().List(), cyan, yellow) +
 		strCol("Vendor [20-35]", s.Vendor.String(), cyan, green) +
-		strCol("Vendor OUI [37-39]", s.VendorOui.String(), cyan, green) +
+		strCol("Vendor Name", s.VendorName(), cyan, green) +
+		strCol("Vendor OUI [37-39]", ouiString(s.VendorOui), cyan, green) +
 		strCol("Vendor PN [40-55]", s.VendorPn.String(), cyan, green) +
-		strCol("Vendor Rev [56-59]", s.VendorRev.String(), cyan, green) +
-		strCol("Option Values [64-65]", fmt.Sprintf("0x%02x 0x%02x", s.Options[0], s.Options[1]), cyan, green) +
+		strCol("Vendor Rev [56-59]", s.VendorRev.String(), cyan, green)
+
+	if s.CopperCable() {
+		str += joinStrCol("Cable Compliance [60-61]", s.CableCompliance(), cyan, green)
+	} else {
+		str += strCol("Laser Wavelength [60-61]", s.LaserWavelength.String(), cyan, green)
+	}
+
+	str += strCol("Option Values [64-65]", fmt.Sprintf("0x%02x 0x%02x", s.Options[0], s.Options[1]), cyan, green) +
+		joinStrCol("Options [64-65]", s.Options.List(), cyan, yellow) +
 		strCol("BR Margin, Max [66]", s.BrMax.String(), cyan, green) +
 		strCol("BR Margin, Min [67]", s.BrMin.String(), cyan, green) +
 		strCol("Vendor SN [68-83]", s.VendorSn.String(), cyan, green) +
-		strCol("Date Code [84-91]", s.DateCode.String(), cyan, green)
-
-	if s.Vendor.String() == "Arista Networks" && strings.HasPrefix(s.VendorPn.String(), "CAB-Q-S-") {
-		str += strCol("Vendor SA [120]", fmt.Sprintf("%x", s.VendorAristaSa), cyan, green)
+		strCol("Date Code [84-91]", s.DateCode.String(), cyan, green) +
+		strCol("Lot Code [90-91]", s.DateCode.Lot(), cyan, green) +
+		joinStrCol("Diagnostic Monitoring Type [92]", s.DiagMonitType.List(), cyan, yellow) +
+		joinStrCol("Enhanced Options [93]", s.EnhancedOpts.List(), cyan, yellow) +
+		strCol("SFF-8472 Compliance [94]", fmt.Sprintf("0x%02x (%s)", byte(s.Sff8472Comp), s.Sff8472Comp), cyan, green)
+
+	for _, f := range s.VendorFields() {
+		str += strCol(f.Key(), f.Value, cyan, green)
 	}
 
 	return str
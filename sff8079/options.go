@@ -0,0 +1,160 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	OptLinearRxOutput   = (1 << 0)
+	OptPowerLevel2      = (1 << 1)
+	OptCooled           = (1 << 2)
+	OptRetimerCdr       = (1 << 3)
+	OptPaging           = (1 << 4)
+	OptPowerLevel3      = (1 << 5)
+	OptRxLos            = (1 << (1 + 8))
+	OptRxLosInverted    = (1 << (2 + 8))
+	OptTxFault          = (1 << (3 + 8))
+	OptTxDisable        = (1 << (4 + 8))
+	OptRateSelect       = (1 << (5 + 8))
+	OptTunable          = (1 << (6 + 8))
+	OptDecisionThreshld = (1 << (7 + 8))
+)
+
+var optionNames = map[uint16]string{
+	OptLinearRxOutput:   "Linear receiver output implemented",
+	OptPowerLevel2:      "Power level 2 requirement",
+	OptCooled:           "Cooled transceiver implemented",
+	OptRetimerCdr:       "Retimer or CDR implemented",
+	OptPaging:           "Paging implemented",
+	OptPowerLevel3:      "Power level 3 requirement",
+	OptRxLos:            "RX_LOS implemented",
+	OptRxLosInverted:    "RX_LOS implemented, inverted",
+	OptTxFault:          "TX_FAULT implemented",
+	OptTxDisable:        "TX_DISABLE implemented",
+	OptRateSelect:       "RATE_SELECT implemented",
+	OptTunable:          "Tunable transmitter technology",
+	OptDecisionThreshld: "Receiver decision threshold implemented",
+}
+
+// Options are the implemented option bits, bytes 64-65.
+type Options [2]byte
+
+func (o Options) Uint16() uint16 {
+	return uint16(o[0]) | uint16(o[1])<<8
+}
+
+// Has returns true if all option bits are set, see OptTxDisable etc.
+func (o Options) Has(opt uint16) bool {
+	return o.Uint16()&opt == opt
+}
+
+func (o Options) List() []string {
+	r := []string{}
+	for i := uint(0); i < 16; i++ {
+		if n, ok := optionNames[1<<i]; ok && o.Has(1<<i) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (o Options) String() string {
+	return strings.Join(o.List(), "\n")
+}
+
+func (o Options) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": o.List(),
+		"hex":    hex.EncodeToString(o[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (o *Options) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then Options type")
+	}
+
+	*o = Options{b[0], b[1]}
+	return nil
+}
+
+const (
+	EnhSoftRateSelect8431 = (1 << 1)
+	EnhAppSelect8079      = (1 << 2)
+	EnhSoftRateSelect     = (1 << 3)
+	EnhSoftRxLos          = (1 << 4)
+	EnhSoftTxFault        = (1 << 5)
+	EnhSoftTxDisable      = (1 << 6)
+	EnhAlarmWarning       = (1 << 7)
+)
+
+var enhancedOptsNames = map[byte]string{
+	EnhSoftRateSelect8431: "Soft rate select control implemented per SFF-8431",
+	EnhAppSelect8079:      "Application select control implemented per SFF-8079",
+	EnhSoftRateSelect:     "Soft RATE_SELECT control and monitoring implemented",
+	EnhSoftRxLos:          "Soft RX_LOS monitoring implemented",
+	EnhSoftTxFault:        "Soft TX_FAULT monitoring implemented",
+	EnhSoftTxDisable:      "Soft TX_DISABLE control and monitoring implemented",
+	EnhAlarmWarning:       "Alarm/warning flags implemented for all monitored quantities",
+}
+
+// EnhancedOpts are the optional features implemented at A2h, byte 93.
+type EnhancedOpts byte
+
+// Has returns true if all option bits are set, see EnhSoftTxDisable etc.
+func (e EnhancedOpts) Has(opt byte) bool {
+	return byte(e)&opt == opt
+}
+
+func (e EnhancedOpts) List() []string {
+	r := []string{}
+	for i := uint(0); i < 8; i++ {
+		if n, ok := enhancedOptsNames[1<<i]; ok && e.Has(1<<i) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (e EnhancedOpts) String() string {
+	return strings.Join(e.List(), "\n")
+}
+
+func (e EnhancedOpts) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": e.List(),
+		"hex":    hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *EnhancedOpts) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*e = EnhancedOpts(b[0])
+	return nil
+}
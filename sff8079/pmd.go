@@ -0,0 +1,181 @@
+package sff8079
+
+import (
+	"sort"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+var extCompliancePmds = map[byte]string{
+	common.ExtCompliance100gAoc5e5:   "25GAUI C2M AOC",
+	common.ExtCompliance100gSr4:      "25GBASE-SR",
+	common.ExtCompliance100gLr4:      "25GBASE-LR",
+	common.ExtCompliance100gEr4:      "25GBASE-ER",
+	common.ExtCompliance100gAcc5e5:   "25GAUI C2M ACC",
+	common.ExtCompliance100gCr4:      "25GBASE-CR CA-25G-L",
+	common.ExtCompliance25gCrS:       "25GBASE-CR CA-25G-S",
+	common.ExtCompliance25gCrN:       "25GBASE-CR CA-25G-N",
+	common.ExtCompliance10gTSfi:      "10GBASE-T",
+	common.ExtCompliance100gAoc1e12:  "25GAUI C2M AOC",
+	common.ExtCompliance100gAcc1e12:  "25GAUI C2M ACC",
+	common.ExtCompliance10gTSr:       "10GBASE-T",
+	common.ExtCompliance5gT:          "5GBASE-T",
+	common.ExtCompliance2g5T:         "2.5GBASE-T",
+	common.ExtCompliance10gBr:        "10GBASE-BR",
+	common.ExtCompliance25gBr:        "25GBASE-BR",
+	common.ExtCompliance50gBr:        "50GBASE-BR",
+	common.ExtComplianceAcc50gAui1e6: "50GAUI C2M ACC",
+	common.ExtComplianceAoc50gAui1e6: "50GAUI C2M AOC",
+	common.ExtComplianceAcc50gAui2e4: "50GAUI C2M ACC",
+	common.ExtComplianceAoc50gAui2e4: "50GAUI C2M AOC",
+	common.ExtCompliance50gCr:        "50GBASE-CR",
+	common.ExtCompliance50gSr:        "50GBASE-SR",
+	common.ExtCompliance50gFr:        "50GBASE-FR",
+	common.ExtCompliance50gLr:        "50GBASE-LR",
+	common.ExtCompliance50gEr:        "50GBASE-ER",
+	common.ExtCompliance100gDr:       "100GBASE-DR",
+	common.ExtCompliance100gFr:       "100GBASE-FR1",
+	common.ExtCompliance100gLr:       "100GBASE-LR1",
+	common.ExtCompliance100gFr1:      "100GBASE-FR1",
+	common.ExtCompliance100gLr1:      "100GBASE-LR1",
+	common.ExtCompliance64gFc:        "64GFC",
+	common.ExtCompliance128gFc:       "128GFC",
+}
+
+var transceiverPmds = []struct {
+	code uint64
+	pmd  string
+}{
+	{Ether10gBaseSr, "10GBASE-SR"},
+	{Ether10gBaeLr, "10GBASE-LR"},
+	{Ether10gBaseLrm, "10GBASE-LRM"},
+	{Ether10gBaseEr, "10GBASE-ER"},
+	{Ether1000BaseSx, "1000BASE-SX"},
+	{Ether1000BaseLx, "1000BASE-LX"},
+	{Ether1000BaseCx, "1000BASE-CX"},
+	{Ether1000BaseT, "1000BASE-T"},
+	{Ether100BaseFx, "100BASE-FX"},
+	{Ether100BaseLx, "100BASE-LX10"},
+	{EtherBaseBx10, "BASE-BX10"},
+	{EtherBasePx, "BASE-PX"},
+}
+
+// ratePmd is a PMD derived from the nominal bit rate in Mb/s, [min, max), and either a
+// copper cable or the wavelength in nm and the SMF length in km.
+type ratePmd struct {
+	min, max     uint
+	copper       bool
+	minNm, maxNm uint16
+	minSmfKm     uint8
+	pmd          string
+}
+
+func (p ratePmd) match(s *Sff8079, br uint, nm uint16) bool {
+	if br < p.min || (p.max != 0 && br >= p.max) {
+		return false
+	}
+	if p.copper {
+		return s.CopperCable()
+	}
+	return nm >= p.minNm && nm <= p.maxNm && uint8(s.LengthSmfKm) >= p.minSmfKm
+}
+
+// ratePmds are checked before the transceiver codes, since 25G modules often set the
+// 10G codes and 10GBASE-ZR has no code of its own.
+var ratePmds = []ratePmd{
+	{min: 24000, copper: true, pmd: "25GBASE-CR"},
+	{min: 24000, minNm: 840, maxNm: 860, pmd: "25GBASE-SR"},
+	{min: 24000, minNm: 1260, maxNm: 1360, pmd: "25GBASE-LR"},
+	{min: 24000, minNm: 1530, maxNm: 1565, pmd: "25GBASE-ER"},
+	{min: 9900, max: 24000, minNm: 1530, maxNm: 1565, minSmfKm: 70, pmd: "10GBASE-ZR"},
+}
+
+// fallbackPmds are checked when no transceiver code is set.
+var fallbackPmds = []ratePmd{
+	{min: 9900, max: 24000, copper: true, pmd: "10GBASE-CU"},
+	{min: 9900, max: 24000, minNm: 840, maxNm: 860, pmd: "10GBASE-SR"},
+	{min: 9900, max: 24000, minNm: 1260, maxNm: 1360, pmd: "10GBASE-LR"},
+	{min: 9900, max: 24000, minNm: 1530, maxNm: 1565, pmd: "10GBASE-ER"},
+	{min: 1000, max: 2500, minNm: 1530, maxNm: 1565, pmd: "1000BASE-ZX"},
+}
+
+// BrNominalMbps returns the nominal bit rate in Mb/s, rates above 25.4 Gb/s
+// are in byte 66 in units of 250 Mb/s when byte 12 is 0xFF.
+func (s *Sff8079) BrNominalMbps() uint {
+	if s.BrNominal == 0xFF && s.BrMax != 0 {
+		return uint(s.BrMax) * 250
+	}
+	return uint(s.BrNominal) * 100
+}
+
+func (s *Sff8079) wavelengthNm() uint16 {
+	if s.Copper() {
+		return 0
+	}
+	return s.LaserWavelength.Nm()
+}
+
+// Pmd returns a best effort physical medium dependent type, such as 25GBASE-LR or 10GBASE-ZR,
+// derived from the compliance codes, nominal bit rate and wavelength. An empty string is
+// returned if it can't be determined.
+func (s *Sff8079) Pmd() string {
+	if p, ok := extCompliancePmds[byte(s.TranscComp)]; ok {
+		return p
+	}
+
+	br := s.BrNominalMbps()
+	nm := s.wavelengthNm()
+	for _, p := range ratePmds {
+		if p.match(s, br, nm) {
+			return p.pmd
+		}
+	}
+
+	t := s.Transceiver.Uint64()
+	for _, p := range transceiverPmds {
+		if t&p.code != 0 {
+			return p.pmd
+		}
+	}
+
+	for _, p := range fallbackPmds {
+		if p.match(s, br, nm) {
+			return p.pmd
+		}
+	}
+	return ""
+}
+
+// Pmds returns the PMD types Pmd can return, sorted.
+func Pmds() []string {
+	seen := map[string]bool{}
+	for _, p := range extCompliancePmds {
+		seen[p] = true
+	}
+	for _, p := range transceiverPmds {
+		seen[p.pmd] = true
+	}
+	for _, p := range ratePmds {
+		seen[p.pmd] = true
+	}
+	for _, p := range fallbackPmds {
+		seen[p.pmd] = true
+	}
+
+	l := []string{}
+	for p := range seen {
+		l = append(l, p)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// TransceiverList returns the transceiver codes, bytes 3-10, together with the
+// extended specification compliance code, byte 36.
+func (s *Sff8079) TransceiverList() []string {
+	l := s.Transceiver.List()
+	if s.TranscComp != common.ExtComplianceUnspecified {
+		l = append(l, "Extended: "+s.TranscComp.String())
+	}
+	return l
+}
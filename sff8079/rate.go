@@ -0,0 +1,110 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	RateUnspecified      = 0x00
+	RateSff8079          = 0x01
+	RateSff8431RxOnly    = 0x02
+	RateSff8431TxOnly    = 0x04
+	RateSff8431RxTx      = 0x06
+	RateFcPi5RxOnly      = 0x08
+	RateFcPi5RxTx        = 0x0A
+	RateFcPi6RxTx        = 0x0C
+	RateCdr10g8gRxTx     = 0x0E
+	RateFcPi7RxTx        = 0x10
+	RatePmdExtCompliance = 0x20
+)
+
+var rateIdentifierNames = map[byte]string{
+	RateUnspecified:      "Unspecified",
+	RateSff8079:          "SFF-8079 (4/2/1G Rate_Select & AS0/AS1)",
+	RateSff8431RxOnly:    "SFF-8431 (8/4/2G Rx Rate_Select only)",
+	RateSff8431TxOnly:    "SFF-8431 (8/4/2G Tx Rate_Select only)",
+	RateSff8431RxTx:      "SFF-8431 (8/4/2G Independent Rx & Tx Rate_Select)",
+	RateFcPi5RxOnly:      "FC-PI-5 (16/8/4G Rx Rate_Select only)",
+	RateFcPi5RxTx:        "FC-PI-5 (16/8/4G Independent Rx & Tx Rate_Select)",
+	RateFcPi6RxTx:        "FC-PI-6 (32/16/8G Independent Rx & Tx Rate_Select)",
+	RateCdr10g8gRxTx:     "10/8G Rx and Tx Rate_Select controlling CDR operation or locking modes",
+	RateFcPi7RxTx:        "FC-PI-7 (64/32/16G Independent Rx & Tx Rate_Select)",
+	RatePmdExtCompliance: "Rate select based on PMDs as defined by A0h byte 36 and A2h byte 67",
+}
+
+var rateIdentifierRates = map[byte][]string{
+	RateSff8079:       {"4GFC", "2GFC", "1GFC"},
+	RateSff8431RxOnly: {"8GFC", "4GFC", "2GFC"},
+	RateSff8431TxOnly: {"8GFC", "4GFC", "2GFC"},
+	RateSff8431RxTx:   {"8GFC", "4GFC", "2GFC"},
+	RateFcPi5RxOnly:   {"16GFC", "8GFC", "4GFC"},
+	RateFcPi5RxTx:     {"16GFC", "8GFC", "4GFC"},
+	RateFcPi6RxTx:     {"32GFC", "16GFC", "8GFC"},
+	RateCdr10g8gRxTx:  {"10G", "8G"},
+	RateFcPi7RxTx:     {"64GFC", "32GFC", "16GFC"},
+}
+
+// RateIdentifier is the rate select functionality, byte 13.
+type RateIdentifier byte
+
+func (r RateIdentifier) String() string {
+	n, ok := rateIdentifierNames[byte(r)]
+	if !ok {
+		return "Unspecified"
+	}
+	return n
+}
+
+// Rates returns the rates selectable with rate select, nil if none are defined.
+func (r RateIdentifier) Rates() []string {
+	return rateIdentifierRates[byte(r)]
+}
+
+func (r RateIdentifier) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": r.String(),
+		"hex":   hex.EncodeToString([]byte{byte(r)}),
+	}
+	if l := r.Rates(); l != nil {
+		m["rates"] = l
+	}
+	return json.Marshal(m)
+}
+
+func (r *RateIdentifier) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*r = RateIdentifier(b[0])
+	return nil
+}
+
+const (
+	RateSelectPin      = "Pin (RS0/RS1)"
+	RateSelectSoft     = "Soft (A2h byte 110 bit 3)"
+	RateSelectSoft8431 = "Soft per SFF-8431 (A2h byte 118 bit 3)"
+)
+
+// RateSelectControl returns how rate select is controlled, see RateSelectPin etc.
+func (s *Sff8079) RateSelectControl() []string {
+	r := []string{}
+	if s.Options.Has(OptRateSelect) {
+		r = append(r, RateSelectPin)
+	}
+	if s.EnhancedOpts.Has(EnhSoftRateSelect) {
+		r = append(r, RateSelectSoft)
+	}
+	if s.EnhancedOpts.Has(EnhSoftRateSelect8431) {
+		r = append(r, RateSelectSoft8431)
+	}
+	return r
+}
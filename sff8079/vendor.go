@@ -0,0 +1,38 @@
+package sff8079
+
+import (
+	"fmt"
+
+	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/vendors"
+)
+
+// VendorName returns the normalized vendor name for grouping modules by vendor.
+func (s *Sff8079) VendorName() string {
+	return common.VendorName(s.Vendor, s.VendorOui)
+}
+
+// VendorModule returns the vendor identification and vendor specific bytes for vendor decoders.
+func (s *Sff8079) VendorModule() *vendors.Module {
+	return &vendors.Module{
+		Vendor:     s.Vendor.String(),
+		Oui:        s.VendorOui,
+		Pn:         s.VendorPn.String(),
+		Rev:        s.VendorRev.String(),
+		Sn:         s.VendorSn.String(),
+		VendorSpec: s.VendorSpec,
+		Offset:     96,
+	}
+}
+
+// VendorFields returns the vendor specific fields decoded by registered vendor decoders.
+func (s *Sff8079) VendorFields() []vendors.Field {
+	return vendors.Decode(s.VendorModule())
+}
+
+func ouiString(v common.VendorOUI) string {
+	if o, ok := v.Organization(); ok {
+		return fmt.Sprintf("%s (%s)", v, o)
+	}
+	return v.String()
+}
@@ -0,0 +1,112 @@
+package sff8079
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+const (
+	PassiveSff8431AppE = (1 << 0)
+	PassiveFcPi4AppH   = (1 << 1)
+	ActiveSff8431AppE  = (1 << 0)
+	ActiveFcPi4AppH    = (1 << 1)
+	ActiveSff8431Lim   = (1 << 2)
+	ActiveFcPi4Lim     = (1 << 3)
+)
+
+var passiveComplianceNames = map[byte]string{
+	PassiveSff8431AppE: "Passive Cable: Compliant to SFF-8431 Appendix E",
+	PassiveFcPi4AppH:   "Passive Cable: Compliant to FC-PI-4 Appendix H",
+}
+
+var activeComplianceNames = map[byte]string{
+	ActiveSff8431AppE: "Active Cable: Compliant to SFF-8431 Appendix E",
+	ActiveFcPi4AppH:   "Active Cable: Compliant to FC-PI-4 Appendix H",
+	ActiveSff8431Lim:  "Active Cable: Compliant to SFF-8431 Limiting",
+	ActiveFcPi4Lim:    "Active Cable: Compliant to FC-PI-4 Limiting",
+}
+
+// Wavelength in nm for optical modules, bytes 60-61.
+type Wavelength [2]byte
+
+// Nm returns the laser wavelength in nm.
+func (w Wavelength) Nm() uint16 {
+	return binary.BigEndian.Uint16(w[:])
+}
+
+func (w Wavelength) String() string {
+	return fmt.Sprintf("%d nm", w.Nm())
+}
+
+func (w Wavelength) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": w.Nm(),
+		"unit":  "nm",
+		"hex":   hex.EncodeToString(w[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (w *Wavelength) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then Wavelength type")
+	}
+
+	*w = Wavelength{b[0], b[1]}
+	return nil
+}
+
+// CopperCable returns true for passive or active copper cables, byte 8 bit 2-3.
+func (s *Sff8079) CopperCable() bool {
+	return s.Transceiver.Uint64()&(PassiveCable|ActiveCable) != 0
+}
+
+// Copper returns true for copper cables and BASE-T modules, using the cable bits, 1000BASE-T,
+// the extended compliance code in byte 36 and an RJ45 connector.
+func (s *Sff8079) Copper() bool {
+	return s.CopperCable() || s.Transceiver.Uint64()&Ether1000BaseT != 0 || s.TranscComp.Copper() ||
+		s.Connector == common.ConnectorRj45
+}
+
+// CableCompliance returns the passive or active cable specification compliance,
+// bytes 60-61 are used for this instead of wavelength for copper cables.
+func (s *Sff8079) CableCompliance() []string {
+	names := passiveComplianceNames
+	if s.Transceiver.Uint64()&ActiveCable != 0 {
+		names = activeComplianceNames
+	}
+
+	r := []string{}
+	for i := uint(0); i < 8; i++ {
+		if n, ok := names[1<<i]; ok && s.LaserWavelength[0]&(1<<i) != 0 {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (s *Sff8079) wavelengthJSON() interface{} {
+	if !s.CopperCable() {
+		return s.LaserWavelength
+	}
+
+	return map[string]interface{}{
+		"values": s.CableCompliance(),
+		"hex":    hex.EncodeToString(s.LaserWavelength[:2]),
+	}
+}
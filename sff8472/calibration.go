@@ -0,0 +1,153 @@
+package sff8472
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// CalConstants is the external calibration constants, bytes 56-91.
+type CalConstants [36]byte
+
+func (c CalConstants) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"hex": hex.EncodeToString(c[:]),
+	}
+	return json.Marshal(m)
+}
+
+func (c *CalConstants) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(in, &m); err != nil {
+		return err
+	}
+
+	s, ok := m["hex"].(string)
+	if !ok {
+		return fmt.Errorf("missing hex value for calibration constants")
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	*c = CalConstants{}
+	copy(c[:], b)
+	return nil
+}
+
+func calFloat(b []byte) float64 {
+	return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+}
+
+func calSlope(b []byte) float64 {
+	return float64(binary.BigEndian.Uint16(b)) / 256
+}
+
+func calOffset(b []byte) float64 {
+	return float64(int16(binary.BigEndian.Uint16(b)))
+}
+
+func calUint16(b []byte, f float64) {
+	binary.BigEndian.PutUint16(b, uint16(math.Max(0, math.Min(math.MaxUint16, f))))
+}
+
+func calInt16(b []byte, f float64) {
+	binary.BigEndian.PutUint16(b, uint16(int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, f)))))
+}
+
+// linear applies slope and offset from the calibration constants at c to the raw value in b.
+func (s *Sff8472) linear(b []byte, c int, signed bool) {
+	slope, offset := calSlope(s.ExtCalConst[c:c+2]), calOffset(s.ExtCalConst[c+2:c+4])
+	if signed {
+		calInt16(b, slope*calOffset(b)+offset)
+		return
+	}
+	calUint16(b, slope*float64(binary.BigEndian.Uint16(b))+offset)
+}
+
+// rxPower applies the 4th order polynomial from the calibration constants to the raw value in b.
+func (s *Sff8472) rxPower(b []byte) {
+	x := float64(binary.BigEndian.Uint16(b))
+	f := 0.0
+	for i := 0; i < 5; i++ {
+		f = f*x + calFloat(s.ExtCalConst[i*4:i*4+4])
+	}
+	calUint16(b, f)
+}
+
+// Calibrate returns a copy with measured values and thresholds converted using the external
+// calibration constants, bytes 56-91. Only use it for modules that are externally calibrated.
+func (s *Sff8472) Calibrate() *Sff8472 {
+	c := *s
+
+	for _, t := range []*[2]byte{(*[2]byte)(&c.Temperature), (*[2]byte)(&c.TempHighAlarm), (*[2]byte)(&c.TempLowAlarm), (*[2]byte)(&c.TempHighWarning), (*[2]byte)(&c.TempLowWarning)} {
+		c.linear(t[:], 28, true)
+	}
+
+	for _, v := range []*[2]byte{(*[2]byte)(&c.Vcc), (*[2]byte)(&c.VccHighAlarm), (*[2]byte)(&c.VccLowAlarm), (*[2]byte)(&c.VccHighWarning), (*[2]byte)(&c.VccLowWarning)} {
+		c.linear(v[:], 32, false)
+	}
+
+	for _, i := range []*[2]byte{(*[2]byte)(&c.TxBias), (*[2]byte)(&c.BiasHighAlarm), (*[2]byte)(&c.BiasLowAlarm), (*[2]byte)(&c.BiasHighWarning), (*[2]byte)(&c.BiasLowWarning)} {
+		c.linear(i[:], 20, false)
+	}
+
+	for _, p := range []*[2]byte{(*[2]byte)(&c.TxPower), (*[2]byte)(&c.TxPwrHighAlarm), (*[2]byte)(&c.TxPwrLowAlarm), (*[2]byte)(&c.TxPwrHighWarning), (*[2]byte)(&c.TxPwrLowWarning)} {
+		c.linear(p[:], 24, false)
+	}
+
+	for _, p := range []*[2]byte{(*[2]byte)(&c.RxPower), (*[2]byte)(&c.RxPwrHighAlarm), (*[2]byte)(&c.RxPwrLowAlarm), (*[2]byte)(&c.RxPwrHighWarning), (*[2]byte)(&c.RxPwrLowWarning)} {
+		c.rxPower(p[:])
+	}
+
+	return &c
+}
+
+// Calibrated marshals an externally calibrated page with the calibrated values, the hex
+// is kept from the raw bytes so unmarshalling restores the page.
+type Calibrated struct {
+	*Sff8472
+}
+
+func (c Calibrated) MarshalJSON() ([]byte, error) {
+	raw, cal := map[string]json.RawMessage{}, map[string]json.RawMessage{}
+	for _, v := range []struct {
+		s *Sff8472
+		m map[string]json.RawMessage
+	}{{c.Sff8472, raw}, {c.Sff8472.Calibrate(), cal}} {
+		b, err := json.Marshal(v.s)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &v.m); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range cal {
+		if bytes.Equal(v, raw[k]) {
+			continue
+		}
+
+		o, r := map[string]interface{}{}, map[string]interface{}{}
+		if err := json.Unmarshal(v, &o); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw[k], &r); err != nil {
+			return nil, err
+		}
+		o["hex"] = r["hex"]
+
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, err
+		}
+		cal[k] = b
+	}
+	return json.Marshal(cal)
+}
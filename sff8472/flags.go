@@ -0,0 +1,159 @@
+package sff8472
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	TempHigh     = (1 << 7)
+	TempLow      = (1 << 6)
+	VccHigh      = (1 << 5)
+	VccLow       = (1 << 4)
+	TxBiasHigh   = (1 << 3)
+	TxBiasLow    = (1 << 2)
+	TxPowerHigh  = (1 << 1)
+	TxPowerLow   = (1 << 0)
+	RxPowerHigh  = (1 << (7 + 8))
+	RxPowerLow   = (1 << (6 + 8))
+	LaserTmpHigh = (1 << (5 + 8))
+	LaserTmpLow  = (1 << (4 + 8))
+	TecCurrHigh  = (1 << (3 + 8))
+	TecCurrLow   = (1 << (2 + 8))
+)
+
+var flagNames = map[uint16]string{
+	TempHigh:     "Temperature high",
+	TempLow:      "Temperature low",
+	VccHigh:      "Vcc high",
+	VccLow:       "Vcc low",
+	TxBiasHigh:   "TX bias high",
+	TxBiasLow:    "TX bias low",
+	TxPowerHigh:  "TX power high",
+	TxPowerLow:   "TX power low",
+	RxPowerHigh:  "RX power high",
+	RxPowerLow:   "RX power low",
+	LaserTmpHigh: "Laser temperature high",
+	LaserTmpLow:  "Laser temperature low",
+	TecCurrHigh:  "TEC current high",
+	TecCurrLow:   "TEC current low",
+}
+
+var flagOrder = []uint16{
+	TempHigh, TempLow, VccHigh, VccLow, TxBiasHigh, TxBiasLow, TxPowerHigh, TxPowerLow,
+	RxPowerHigh, RxPowerLow, LaserTmpHigh, LaserTmpLow, TecCurrHigh, TecCurrLow,
+}
+
+// Flags are the alarm or warning flags, bytes 112-113 or 116-117.
+type Flags [2]byte
+
+func (f Flags) Uint16() uint16 {
+	return uint16(f[0]) | uint16(f[1])<<8
+}
+
+func (f Flags) List() []string {
+	r := []string{}
+	for _, k := range flagOrder {
+		if k&f.Uint16() != 0 {
+			r = append(r, flagNames[k])
+		}
+	}
+	return r
+}
+
+func (f Flags) String() string {
+	return strings.Join(f.List(), "\n")
+}
+
+func (f Flags) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": f.List(),
+		"hex":    hex.EncodeToString(f[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (f *Flags) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then Flags type")
+	}
+
+	*f = Flags{b[0], b[1]}
+	return nil
+}
+
+const (
+	TxDisableState  = (1 << 7)
+	SoftTxDisable   = (1 << 6)
+	Rs1State        = (1 << 5)
+	RateSelectState = (1 << 4)
+	SoftRateSelect  = (1 << 3)
+	TxFaultState    = (1 << 2)
+	RxLosState      = (1 << 1)
+	DataNotReady    = (1 << 0)
+)
+
+var statusControlNames = map[byte]string{
+	TxDisableState:  "TX_DISABLE asserted",
+	SoftTxDisable:   "Soft TX_DISABLE selected",
+	Rs1State:        "RS(1) asserted",
+	RateSelectState: "Rate_Select asserted",
+	SoftRateSelect:  "Soft Rate_Select selected",
+	TxFaultState:    "TX_FAULT asserted",
+	RxLosState:      "RX_LOS asserted",
+	DataNotReady:    "Data not ready",
+}
+
+// StatusControl is the optional status/control bits, byte 110.
+type StatusControl byte
+
+func (s StatusControl) List() []string {
+	r := []string{}
+	for i := 7; i >= 0; i-- {
+		if byte(s)&(1<<uint(i)) != 0 {
+			r = append(r, statusControlNames[1<<uint(i)])
+		}
+	}
+	return r
+}
+
+func (s StatusControl) String() string {
+	return strings.Join(s.List(), "\n")
+}
+
+func (s StatusControl) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": s.List(),
+		"hex":    hex.EncodeToString([]byte{byte(s)}),
+	}
+	return json.Marshal(m)
+}
+
+func (s *StatusControl) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*s = StatusControl(b[0])
+	return nil
+}
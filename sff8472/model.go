@@ -0,0 +1,141 @@
+package sff8472
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/mickep76/go-sff/common"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	red     = "\x1b[31m"
+	green   = "\x1b[32m"
+	yellow  = "\x1b[33m"
+	blue    = "\x1b[34m"
+	magenta = "\x1b[35m"
+	cyan    = "\x1b[36m"
+	white   = "\x1b[37m"
+	clear   = "\x1b[0m"
+)
+
+// Sff8472 is the diagnostic monitoring interface at 2-wire address A2h.
+type Sff8472 struct {
+	TempHighAlarm    common.Temperature `json:"tempHighAlarm"`    // 0-1 - Temp High Alarm
+	TempLowAlarm     common.Temperature `json:"tempLowAlarm"`     // 2-3 - Temp Low Alarm
+	TempHighWarning  common.Temperature `json:"tempHighWarning"`  // 4-5 - Temp High Warning
+	TempLowWarning   common.Temperature `json:"tempLowWarning"`   // 6-7 - Temp Low Warning
+	VccHighAlarm     common.Voltage     `json:"vccHighAlarm"`     // 8-9 - Voltage High Alarm
+	VccLowAlarm      common.Voltage     `json:"vccLowAlarm"`      // 10-11 - Voltage Low Alarm
+	VccHighWarning   common.Voltage     `json:"vccHighWarning"`   // 12-13 - Voltage High Warning
+	VccLowWarning    common.Voltage     `json:"vccLowWarning"`    // 14-15 - Voltage Low Warning
+	BiasHighAlarm    common.Current     `json:"biasHighAlarm"`    // 16-17 - Bias High Alarm
+	BiasLowAlarm     common.Current     `json:"biasLowAlarm"`     // 18-19 - Bias Low Alarm
+	BiasHighWarning  common.Current     `json:"biasHighWarning"`  // 20-21 - Bias High Warning
+	BiasLowWarning   common.Current     `json:"biasLowWarning"`   // 22-23 - Bias Low Warning
+	TxPwrHighAlarm   common.Power       `json:"txPwrHighAlarm"`   // 24-25 - TX Power High Alarm
+	TxPwrLowAlarm    common.Power       `json:"txPwrLowAlarm"`    // 26-27 - TX Power Low Alarm
+	TxPwrHighWarning common.Power       `json:"txPwrHighWarning"` // 28-29 - TX Power High Warning
+	TxPwrLowWarning  common.Power       `json:"txPwrLowWarning"`  // 30-31 - TX Power Low Warning
+	RxPwrHighAlarm   common.Power       `json:"rxPwrHighAlarm"`   // 32-33 - RX Power High Alarm
+	RxPwrLowAlarm    common.Power       `json:"rxPwrLowAlarm"`    // 34-35 - RX Power Low Alarm
+	RxPwrHighWarning common.Power       `json:"rxPwrHighWarning"` // 36-37 - RX Power High Warning
+	RxPwrLowWarning  common.Power       `json:"rxPwrLowWarning"`  // 38-39 - RX Power Low Warning
+	OptThresholds    [16]byte           `json:"-"`                // 40-55 - Optional Laser Temp and TEC Current Thresholds
+	ExtCalConst      CalConstants       `json:"extCalConst"`      // 56-91 - Ext Cal Constants
+	Unallocated      [3]byte            `json:"-"`                // 92-94 - Unallocated
+	CcDmi            common.Checksum    `json:"ccDmi"`            // 95 - CC_DMI
+	Temperature      common.Temperature `json:"temperature"`      // 96-97 - Temperature
+	Vcc              common.Voltage     `json:"vcc"`              // 98-99 - Vcc
+	TxBias           common.Current     `json:"txBias"`           // 100-101 - TX Bias
+	TxPower          common.Power       `json:"txPower"`          // 102-103 - TX Power
+	RxPower          common.Power       `json:"rxPower"`          // 104-105 - RX Power
+	OptMonitors      [4]byte            `json:"-"`                // 106-109 - Optional Laser Temp and TEC Current
+	StatusControl    StatusControl      `json:"statusControl"`    // 110 - Status/Control
+	Reserved1        byte               `json:"-"`                // 111 - Reserved
+	AlarmFlags       Flags              `json:"alarmFlags"`       // 112-113 - Alarm Flags
+	TxInputEq        byte               `json:"-"`                // 114 - Tx Input Equalization Control
+	RxOutEmph        byte               `json:"-"`                // 115 - Rx Output Emphasis Control
+	WarningFlags     Flags              `json:"warningFlags"`     // 116-117 - Warning Flags
+	ExtStatusControl [2]byte            `json:"-"`                // 118-119 - Ext Status/Control
+	VendorSpec       [7]byte            `json:"-"`                // 120-126 - Vendor Specific
+	TableSelect      byte               `json:"-"`                // 127 - Table Select
+	UserEeprom       [120]byte          `json:"-"`                // 128-247 - User EEPROM
+	VendorControl    [8]byte            `json:"-"`                // 248-255 - Vendor Control Functions
+}
+
+// Decode the diagnostic page, eeprom should start at A2h byte 0.
+func Decode(eeprom []byte) (*Sff8472, error) {
+	if len(eeprom) < 256 {
+		return nil, fmt.Errorf("eeprom size to small needs to be 256 bytes or larger got: %d bytes", len(eeprom))
+	}
+
+	return (*Sff8472)(unsafe.Pointer(&eeprom[0])), nil
+}
+
+func (s *Sff8472) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(s)
+}
+
+func (s *Sff8472) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, s)
+}
+
+func (s *Sff8472) String() string {
+	return fmt.Sprintf("%-50s : %s\n", "Temperature [96-97]", s.Temperature) +
+		fmt.Sprintf("%-50s : %s\n", "Vcc [98-99]", s.Vcc) +
+		fmt.Sprintf("%-50s : %s\n", "TX Bias [100-101]", s.TxBias) +
+		fmt.Sprintf("%-50s : %s\n", "TX Power [102-103]", s.TxPower) +
+		fmt.Sprintf("%-50s : %s\n", "RX Power [104-105]", s.RxPower) +
+		fmt.Sprintf("%-50s : %s\n", "Status/Control [110]", strings.Join(s.StatusControl.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Alarm Flags [112-113]", strings.Join(s.AlarmFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Warning Flags [116-117]", strings.Join(s.WarningFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Temp High Alarm/Warning [0-1/4-5]", s.TempHighAlarm, s.TempHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Temp Low Alarm/Warning [2-3/6-7]", s.TempLowAlarm, s.TempLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Vcc High Alarm/Warning [8-9/12-13]", s.VccHighAlarm, s.VccHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Vcc Low Alarm/Warning [10-11/14-15]", s.VccLowAlarm, s.VccLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Bias High Alarm/Warning [16-17/20-21]", s.BiasHighAlarm, s.BiasHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Bias Low Alarm/Warning [18-19/22-23]", s.BiasLowAlarm, s.BiasLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Power High Alarm/Warning [24-25/28-29]", s.TxPwrHighAlarm, s.TxPwrHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Power Low Alarm/Warning [26-27/30-31]", s.TxPwrLowAlarm, s.TxPwrLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "RX Power High Alarm/Warning [32-33/36-37]", s.RxPwrHighAlarm, s.RxPwrHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "RX Power Low Alarm/Warning [34-35/38-39]", s.RxPwrLowAlarm, s.RxPwrLowWarning)
+}
+
+func strCol(k string, v string, c1 string, c2 string) string {
+	return fmt.Sprintf("%s%-50s%s : %s%s%s\n", c1, k, clear, c2, v, clear)
+}
+
+func joinStrCol(k string, l []string, c1 string, c2 string) string {
+	if len(l) < 1 {
+		return ""
+	}
+
+	r := strCol(k, l[0], c1, c2)
+	for _, s := range l[1:] {
+		r += strCol("", s, c1, c2)
+	}
+	return r
+}
+
+func (s *Sff8472) StringCol() string {
+	return strCol("Temperature [96-97]", s.Temperature.String(), cyan, green) +
+		strCol("Vcc [98-99]", s.Vcc.String(), cyan, green) +
+		strCol("TX Bias [100-101]", s.TxBias.String(), cyan, green) +
+		strCol("TX Power [102-103]", s.TxPower.String(), cyan, green) +
+		strCol("RX Power [104-105]", s.RxPower.String(), cyan, green) +
+		joinStrCol("Status/Control [110]", s.StatusControl.List(), cyan, yellow) +
+		joinStrCol("Alarm Flags [112-113]", s.AlarmFlags.List(), cyan, red) +
+		joinStrCol("Warning Flags [116-117]", s.WarningFlags.List(), cyan, yellow) +
+		strCol("Temp High Alarm/Warning [0-1/4-5]", fmt.Sprintf("%s / %s", s.TempHighAlarm, s.TempHighWarning), cyan, green) +
+		strCol("Temp Low Alarm/Warning [2-3/6-7]", fmt.Sprintf("%s / %s", s.TempLowAlarm, s.TempLowWarning), cyan, green) +
+		strCol("Vcc High Alarm/Warning [8-9/12-13]", fmt.Sprintf("%s / %s", s.VccHighAlarm, s.VccHighWarning), cyan, green) +
+		strCol("Vcc Low Alarm/Warning [10-11/14-15]", fmt.Sprintf("%s / %s", s.VccLowAlarm, s.VccLowWarning), cyan, green) +
+		strCol("Bias High Alarm/Warning [16-17/20-21]", fmt.Sprintf("%s / %s", s.BiasHighAlarm, s.BiasHighWarning), cyan, green) +
+		strCol("Bias Low Alarm/Warning [18-19/22-23]", fmt.Sprintf("%s / %s", s.BiasLowAlarm, s.BiasLowWarning), cyan, green) +
+		strCol("TX Power High Alarm/Warning [24-25/28-29]", fmt.Sprintf("%s / %s", s.TxPwrHighAlarm, s.TxPwrHighWarning), cyan, green) +
+		strCol("TX Power Low Alarm/Warning [26-27/30-31]", fmt.Sprintf("%s / %s", s.TxPwrLowAlarm, s.TxPwrLowWarning), cyan, green) +
+		strCol("RX Power High Alarm/Warning [32-33/36-37]", fmt.Sprintf("%s / %s", s.RxPwrHighAlarm, s.RxPwrHighWarning), cyan, green) +
+		strCol("RX Power Low Alarm/Warning [34-35/38-39]", fmt.Sprintf("%s / %s", s.RxPwrLowAlarm, s.RxPwrLowWarning), cyan, green)
+}
@@ -0,0 +1,26 @@
+package sff8636
+
+import (
+	"unsafe"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+func (s *Sff8636) bytes() []byte {
+	return (*[128]byte)(unsafe.Pointer(s))[:]
+}
+
+// Bytes returns a copy of the page bytes.
+func (s *Sff8636) Bytes() []byte {
+	return append([]byte{}, s.bytes()...)
+}
+
+// ChecksumBase returns the calculated checksum for bytes 128-190, compare with CcBase.
+func (s *Sff8636) ChecksumBase() common.Checksum {
+	return common.CalculateChecksum(s.bytes()[0:63])
+}
+
+// ChecksumExt returns the calculated checksum for bytes 192-222, compare with CcExt.
+func (s *Sff8636) ChecksumExt() common.Checksum {
+	return common.CalculateChecksum(s.bytes()[64:95])
+}
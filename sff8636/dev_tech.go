@@ -0,0 +1,214 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	TxTechMask              = 0xF0
+	TxTech850nmVcsel        = (0x0 << 4)
+	TxTech1310nmVcsel       = (0x1 << 4)
+	TxTech1550nmVcsel       = (0x2 << 4)
+	TxTech1310nmFp          = (0x3 << 4)
+	TxTech1310nmDfb         = (0x4 << 4)
+	TxTech1550nmDfb         = (0x5 << 4)
+	TxTech1310nmEml         = (0x6 << 4)
+	TxTech1550nmEml         = (0x7 << 4)
+	TxTechOther             = (0x8 << 4)
+	TxTech1490nmDfb         = (0x9 << 4)
+	TxTechCopperUnequalized = (0xA << 4)
+	TxTechCopperPassiveEq   = (0xB << 4)
+	TxTechCopperNearFarEq   = (0xC << 4)
+	TxTechCopperFarEq       = (0xD << 4)
+	TxTechCopperNearEq      = (0xE << 4)
+	TxTechCopperLinearEq    = (0xF << 4)
+
+	WavelengthControl = (1 << 3)
+	CooledTx          = (1 << 2)
+	ApdDetector       = (1 << 1)
+	TunableTx         = (1 << 0)
+)
+
+var txTechNames = map[byte]string{
+	TxTech850nmVcsel:        "850 nm VCSEL",
+	TxTech1310nmVcsel:       "1310 nm VCSEL",
+	TxTech1550nmVcsel:       "1550 nm VCSEL",
+	TxTech1310nmFp:          "1310 nm FP",
+	TxTech1310nmDfb:         "1310 nm DFB",
+	TxTech1550nmDfb:         "1550 nm DFB",
+	TxTech1310nmEml:         "1310 nm EML",
+	TxTech1550nmEml:         "1550 nm EML",
+	TxTechOther:             "Other / Undefined",
+	TxTech1490nmDfb:         "1490 nm DFB",
+	TxTechCopperUnequalized: "Copper cable unequalized",
+	TxTechCopperPassiveEq:   "Copper cable passive equalized",
+	TxTechCopperNearFarEq:   "Copper cable, near and far end limiting active equalizers",
+	TxTechCopperFarEq:       "Copper cable, far end limiting active equalizers",
+	TxTechCopperNearEq:      "Copper cable, near end limiting active equalizers",
+	TxTechCopperLinearEq:    "Copper cable, linear active equalizers",
+}
+
+var devTechNames = map[byte][2]string{
+	WavelengthControl: {"No wavelength control", "Active wavelength control"},
+	CooledTx:          {"Uncooled transmitter device", "Cooled transmitter"},
+	ApdDetector:       {"Pin detector", "APD detector"},
+	TunableTx:         {"Transmitter not tunable", "Transmitter tunable"},
+}
+
+// DevTech is the device technology, byte 147.
+type DevTech byte
+
+// TxTech returns the transmitter technology bits, see TxTech850nmVcsel etc.
+func (d DevTech) TxTech() byte {
+	return byte(d) & TxTechMask
+}
+
+// Copper returns true if the transmitter technology is a copper cable.
+func (d DevTech) Copper() bool {
+	return d.TxTech() >= TxTechCopperUnequalized
+}
+
+func (d DevTech) List() []string {
+	r := []string{txTechNames[d.TxTech()]}
+	for _, k := range []byte{WavelengthControl, CooledTx, ApdDetector, TunableTx} {
+		if byte(d)&k != 0 {
+			r = append(r, devTechNames[k][1])
+		} else {
+			r = append(r, devTechNames[k][0])
+		}
+	}
+	return r
+}
+
+func (d DevTech) String() string {
+	return strings.Join(d.List(), "\n")
+}
+
+func (d DevTech) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": d.List(),
+		"hex":    hex.EncodeToString([]byte{byte(d)}),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DevTech) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*d = DevTech(b[0])
+	return nil
+}
+
+const (
+	ExtModuleSdr = (1 << 0)
+	ExtModuleDdr = (1 << 1)
+	ExtModuleQdr = (1 << 2)
+	ExtModuleFdr = (1 << 3)
+	ExtModuleEdr = (1 << 4)
+	ExtModuleHdr = (1 << 5)
+)
+
+var extModuleNames = map[byte]string{
+	ExtModuleSdr: "InfiniBand SDR",
+	ExtModuleDdr: "InfiniBand DDR",
+	ExtModuleQdr: "InfiniBand QDR",
+	ExtModuleFdr: "InfiniBand FDR",
+	ExtModuleEdr: "InfiniBand EDR",
+	ExtModuleHdr: "InfiniBand HDR",
+}
+
+// ExtModule are the extended module codes for InfiniBand, byte 164.
+type ExtModule byte
+
+func (e ExtModule) List() []string {
+	r := []string{}
+	for i := uint(0); i < 8; i++ {
+		if n, ok := extModuleNames[1<<i]; ok && byte(e)&(1<<i) != 0 {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (e ExtModule) String() string {
+	return strings.Join(e.List(), "\n")
+}
+
+func (e ExtModule) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": e.List(),
+		"hex":    hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *ExtModule) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*e = ExtModule(b[0])
+	return nil
+}
+
+// MaxCaseTemp is the maximum case temperature in degrees Celsius, byte 190. Zero means the default of 70 C.
+type MaxCaseTemp byte
+
+// Celsius returns the maximum case temperature with the default applied.
+func (t MaxCaseTemp) Celsius() uint8 {
+	if t == 0 {
+		return 70
+	}
+	return uint8(t)
+}
+
+func (t MaxCaseTemp) String() string {
+	if t == 0 {
+		return "70 C (default)"
+	}
+	return fmt.Sprintf("%d C", t.Celsius())
+}
+
+func (t MaxCaseTemp) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": t.Celsius(),
+		"unit":  "C",
+		"hex":   hex.EncodeToString([]byte{byte(t)}),
+	}
+	return json.Marshal(m)
+}
+
+func (t *MaxCaseTemp) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*t = MaxCaseTemp(b[0])
+	return nil
+}
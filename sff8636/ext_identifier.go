@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/mickep76/go-sff/common"
 )
 
 const (
@@ -14,6 +16,9 @@ const (
 	PwrClass3    = (2 << 6)
 	PwrClass4    = (3 << 6)
 
+	PwrClass8Mask = 0x20
+	PwrClass8     = (1 << 5)
+
 	ClieCodeMask = 0x10
 	NoClieCode   = (0 << 4)
 	ClieCode     = (1 << 4)
@@ -62,8 +67,36 @@ var extPwrClassNames = map[byte]string{
 	ExtPwrClass7:      "5.0 W max. power consumption",
 }
 
+var pwrClassWatts = map[uint8]common.Watts{
+	1: 1.5,
+	2: 2.0,
+	3: 2.5,
+	4: 3.5,
+	5: 4.0,
+	6: 4.5,
+	7: 5.0,
+}
+
 type ExtIdentifier byte
 
+// PowerClass returns the power class 1-8, class 8 has the max power in byte 107.
+func (e ExtIdentifier) PowerClass() uint8 {
+	b := byte(e)
+	switch {
+	case b&PwrClass8Mask == PwrClass8:
+		return 8
+	case b&ExtPwrClassMask != ExtPwrClassUnused:
+		return 4 + b&ExtPwrClassMask
+	}
+	return 1 + (b&PwrClassMask)>>6
+}
+
+// MaxPower returns the max power consumption for power class 1-7, zero for power class 8
+// see LowerPage.MaxPower.
+func (e ExtIdentifier) MaxPower() common.Watts {
+	return pwrClassWatts[e.PowerClass()]
+}
+
 func (e ExtIdentifier) List() []string {
 	b := byte(e)
 	s := []string{
@@ -76,6 +109,10 @@ func (e ExtIdentifier) List() []string {
 		s = append(s, extPwrClassNames[b&ExtPwrClassMask])
 	}
 
+	if b&PwrClass8Mask == PwrClass8 {
+		s = append(s, "Power class 8 implemented, max. power consumption in byte 107")
+	}
+
 	return s
 }
 
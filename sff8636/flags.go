@@ -0,0 +1,207 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	HighAlarm   = (1 << 3)
+	LowAlarm    = (1 << 2)
+	HighWarning = (1 << 1)
+	LowWarning  = (1 << 0)
+)
+
+var levelNames = map[byte]string{
+	HighAlarm:   "High alarm",
+	LowAlarm:    "Low alarm",
+	HighWarning: "High warning",
+	LowWarning:  "Low warning",
+}
+
+func levelList(prefix string, n byte) []string {
+	r := []string{}
+	for _, k := range []byte{HighAlarm, LowAlarm, HighWarning, LowWarning} {
+		if n&k != 0 {
+			r = append(r, prefix+levelNames[k])
+		}
+	}
+	return r
+}
+
+// LevelFlags are alarm and warning flags in the upper nibble, bytes 6-7.
+type LevelFlags byte
+
+// Level returns the alarm and warning bits, see HighAlarm etc.
+func (l LevelFlags) Level() byte {
+	return byte(l) >> 4
+}
+
+func (l LevelFlags) List() []string {
+	return levelList("", l.Level())
+}
+
+func (l LevelFlags) String() string {
+	return strings.Join(l.List(), "\n")
+}
+
+func (l LevelFlags) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": l.List(),
+		"hex":    hex.EncodeToString([]byte{byte(l)}),
+	}
+	return json.Marshal(m)
+}
+
+func (l *LevelFlags) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*l = LevelFlags(b[0])
+	return nil
+}
+
+// ChannelFlags are alarm and warning flags per channel, bytes 9-10, 11-12 and 13-14.
+type ChannelFlags [2]byte
+
+// Level returns the alarm and warning bits for channel 1-4, see HighAlarm etc.
+func (c ChannelFlags) Level(ch int) byte {
+	if ch < 1 || ch > 4 {
+		return 0
+	}
+	b := c[(ch-1)/2]
+	if ch%2 == 1 {
+		return b >> 4
+	}
+	return b & 0x0f
+}
+
+func (c ChannelFlags) List() []string {
+	r := []string{}
+	for ch := 1; ch <= 4; ch++ {
+		r = append(r, levelList(fmt.Sprintf("Channel %d: ", ch), c.Level(ch))...)
+	}
+	return r
+}
+
+func (c ChannelFlags) String() string {
+	return strings.Join(c.List(), "\n")
+}
+
+func (c ChannelFlags) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": c.List(),
+		"hex":    hex.EncodeToString(c[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (c *ChannelFlags) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then ChannelFlags type")
+	}
+
+	*c = ChannelFlags{b[0], b[1]}
+	return nil
+}
+
+// ChannelStatus has TX channel 4-1 in bits 7-4 and RX channel 4-1 in bits 3-0, bytes 3-5.
+type ChannelStatus byte
+
+// Tx returns true if the bit is set for TX channel 1-4.
+func (c ChannelStatus) Tx(ch int) bool {
+	return ch >= 1 && ch <= 4 && byte(c)&(1<<uint(ch+3)) != 0
+}
+
+// Rx returns true if the bit is set for RX channel 1-4.
+func (c ChannelStatus) Rx(ch int) bool {
+	return ch >= 1 && ch <= 4 && byte(c)&(1<<uint(ch-1)) != 0
+}
+
+func (c ChannelStatus) List() []string {
+	r := []string{}
+	for ch := 1; ch <= 4; ch++ {
+		if c.Tx(ch) {
+			r = append(r, fmt.Sprintf("TX channel %d", ch))
+		}
+	}
+	for ch := 1; ch <= 4; ch++ {
+		if c.Rx(ch) {
+			r = append(r, fmt.Sprintf("RX channel %d", ch))
+		}
+	}
+	return r
+}
+
+func (c ChannelStatus) String() string {
+	return strings.Join(c.List(), "\n")
+}
+
+func (c ChannelStatus) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": c.List(),
+		"hex":    hex.EncodeToString([]byte{byte(c)}),
+	}
+	return json.Marshal(m)
+}
+
+func (c *ChannelStatus) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*c = ChannelStatus(b[0])
+	return nil
+}
+
+func (l *LowerPage) flags(mask byte) []string {
+	r := levelList("Temperature: ", l.TempFlags.Level()&mask)
+	r = append(r, levelList("Vcc: ", l.VccFlags.Level()&mask)...)
+	for _, f := range []struct {
+		name  string
+		flags ChannelFlags
+	}{{"RX power", l.RxPowerFlags}, {"TX bias", l.TxBiasFlags}, {"TX power", l.TxPowerFlags}} {
+		for ch := 1; ch <= 4; ch++ {
+			r = append(r, levelList(fmt.Sprintf("%s channel %d: ", f.name, ch), f.flags.Level(ch)&mask)...)
+		}
+	}
+	return r
+}
+
+// Alarms returns the active high and low alarm flags.
+func (l *LowerPage) Alarms() []string {
+	return l.flags(HighAlarm | LowAlarm)
+}
+
+// Warnings returns the active high and low warning flags.
+func (l *LowerPage) Warnings() []string {
+	return l.flags(HighWarning | LowWarning)
+}
@@ -0,0 +1,51 @@
+package sff8636
+
+import (
+	"fmt"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+// LengthOm4OrCopper returns byte 146 as a copper cable length in units of 1 m or
+// an OM4 length in units of 2 m.
+func (s *Sff8636) LengthOm4OrCopper() common.Length {
+	if s.Copper() {
+		return s.LengthCopper
+	}
+	return common.Value2m(s.LengthCopper)
+}
+
+func (s *Sff8636) lengthOm4OrCopperKey() string {
+	if s.Copper() {
+		return "Length (Copper or Active cable) [146]"
+	}
+	return "Length (OM4 50um) [146]"
+}
+
+func (s *Sff8636) lengthOm1Key() string {
+	if s.Copper() {
+		return "Attenuation at 25.78 GHz [145]"
+	}
+	return "Length (OM1 62.5um) [145]"
+}
+
+func (s *Sff8636) lengthOm1String() string {
+	if s.Copper() {
+		return fmt.Sprintf("%d dB", uint8(s.LengthOm1))
+	}
+	return s.LengthOm1.String()
+}
+
+// Reach returns the supported link lengths per medium, byte 145 is ignored for
+// copper cables since it holds the cable attenuation.
+func (s *Sff8636) Reach() common.Reaches {
+	r := common.Reaches{}.Add(common.MediumSmf, s.LengthSmf)
+	if s.Copper() {
+		return r.Add(common.MediumCopper, s.LengthCopper)
+	}
+
+	return r.Add(common.MediumOm1, s.LengthOm1).
+		Add(common.MediumOm2, s.LengthOm2).
+		Add(common.MediumOm3, s.LengthOm3).
+		Add(common.MediumOm4, common.Value2m(s.LengthCopper))
+}
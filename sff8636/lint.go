@@ -0,0 +1,13 @@
+package sff8636
+
+import (
+	"github.com/mickep76/go-sff/common"
+)
+
+// Lint returns the issues found in the ASCII fields.
+func (s *Sff8636) Lint() []common.Issue {
+	r := common.LintASCII("Vendor", 148, s.Vendor[:])
+	r = append(r, common.LintASCII("VendorPn", 168, s.VendorPn[:])...)
+	r = append(r, common.LintASCII("VendorRev", 184, s.VendorRev[:])...)
+	return append(r, common.LintASCII("VendorSn", 196, s.VendorSn[:])...)
+}
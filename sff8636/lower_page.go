@@ -0,0 +1,104 @@
+package sff8636
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/mickep76/go-sff/common"
+	"gopkg.in/yaml.v3"
+)
+
+// LowerPage is the lower memory page, bytes 0-127.
+type LowerPage struct {
+	Identifier   common.Identifier  `json:"-"`            // 0 - Identifier
+	Status       [2]byte            `json:"-"`            // 1-2 - Status
+	Los          ChannelStatus      `json:"los"`          // 3 - TX/RX LOS
+	Fault        ChannelStatus      `json:"fault"`        // 4 - TX Adapt EQ Fault/TX Fault
+	Lol          ChannelStatus      `json:"lol"`          // 5 - TX/RX CDR LOL
+	TempFlags    LevelFlags         `json:"tempFlags"`    // 6 - Temperature Flags
+	VccFlags     LevelFlags         `json:"vccFlags"`     // 7 - Supply Voltage Flags
+	VendorSpec1  byte               `json:"-"`            // 8 - Vendor Specific
+	RxPowerFlags ChannelFlags       `json:"rxPowerFlags"` // 9-10 - RX Power Flags
+	TxBiasFlags  ChannelFlags       `json:"txBiasFlags"`  // 11-12 - TX Bias Flags
+	TxPowerFlags ChannelFlags       `json:"txPowerFlags"` // 13-14 - TX Power Flags
+	Reserved1    [4]byte            `json:"-"`            // 15-18 - Reserved
+	VendorSpec2  [3]byte            `json:"-"`            // 19-21 - Vendor Specific
+	Temperature  common.Temperature `json:"temperature"`  // 22-23 - Temperature
+	Reserved2    [2]byte            `json:"-"`            // 24-25 - Reserved
+	Vcc          common.Voltage     `json:"vcc"`          // 26-27 - Supply Voltage
+	Reserved3    [2]byte            `json:"-"`            // 28-29 - Reserved
+	VendorSpec3  [4]byte            `json:"-"`            // 30-33 - Vendor Specific
+	RxPower      [4]common.Power    `json:"rxPower"`      // 34-41 - RX Power Channel 1-4
+	TxBias       [4]common.Current  `json:"txBias"`       // 42-49 - TX Bias Channel 1-4
+	TxPower      [4]common.Power    `json:"txPower"`      // 50-57 - TX Power Channel 1-4
+	Reserved4    [24]byte           `json:"-"`            // 58-81 - Reserved
+	VendorSpec4  [4]byte            `json:"-"`            // 82-85 - Vendor Specific
+	Control      [21]byte           `json:"-"`            // 86-106 - Control and Masks
+	MaxPower     MaxPower           `json:"maxPower"`     // 107 - Max Power Consumption
+	Reserved5    [19]byte           `json:"-"`            // 108-126 - Reserved
+	PageSelect   byte               `json:"-"`            // 127 - Page Select
+}
+
+// DecodeLowerPage decodes the lower page, eeprom should start at byte 0.
+func DecodeLowerPage(eeprom []byte) (*LowerPage, error) {
+	if len(eeprom) < 128 {
+		return nil, fmt.Errorf("eeprom size to small needs to be 128 bytes or larger got: %d bytes", len(eeprom))
+	}
+
+	return (*LowerPage)(unsafe.Pointer(&eeprom[0])), nil
+}
+
+func (l *LowerPage) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(l)
+}
+
+func (l *LowerPage) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, l)
+}
+
+func (l *LowerPage) String() string {
+	str := fmt.Sprintf("%-50s : %s\n", "Temperature [22-23]", l.Temperature) +
+		fmt.Sprintf("%-50s : %s\n", "Supply Voltage [26-27]", l.Vcc)
+	for i := 0; i < 4; i++ {
+		str += fmt.Sprintf("%-50s : %s\n", fmt.Sprintf("RX Power Channel %d [%d-%d]", i+1, 34+i*2, 35+i*2), l.RxPower[i])
+	}
+	for i := 0; i < 4; i++ {
+		str += fmt.Sprintf("%-50s : %s\n", fmt.Sprintf("TX Bias Channel %d [%d-%d]", i+1, 42+i*2, 43+i*2), l.TxBias[i])
+	}
+	for i := 0; i < 4; i++ {
+		str += fmt.Sprintf("%-50s : %s\n", fmt.Sprintf("TX Power Channel %d [%d-%d]", i+1, 50+i*2, 51+i*2), l.TxPower[i])
+	}
+	return str +
+		fmt.Sprintf("%-50s : %s\n", "LOS [3]", strings.Join(l.Los.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Fault [4]", strings.Join(l.Fault.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "CDR LOL [5]", strings.Join(l.Lol.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Temperature Flags [6]", strings.Join(l.TempFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Supply Voltage Flags [7]", strings.Join(l.VccFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "RX Power Flags [9-10]", strings.Join(l.RxPowerFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "TX Bias Flags [11-12]", strings.Join(l.TxBiasFlags.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "TX Power Flags [13-14]", strings.Join(l.TxPowerFlags.List(), fmt.Sprintf("\n%-50s : ", " ")))
+}
+
+func (l *LowerPage) StringCol() string {
+	str := strCol("Temperature [22-23]", l.Temperature.String(), cyan, green) +
+		strCol("Supply Voltage [26-27]", l.Vcc.String(), cyan, green)
+	for i := 0; i < 4; i++ {
+		str += strCol(fmt.Sprintf("RX Power Channel %d [%d-%d]", i+1, 34+i*2, 35+i*2), l.RxPower[i].String(), cyan, green)
+	}
+	for i := 0; i < 4; i++ {
+		str += strCol(fmt.Sprintf("TX Bias Channel %d [%d-%d]", i+1, 42+i*2, 43+i*2), l.TxBias[i].String(), cyan, green)
+	}
+	for i := 0; i < 4; i++ {
+		str += strCol(fmt.Sprintf("TX Power Channel %d [%d-%d]", i+1, 50+i*2, 51+i*2), l.TxPower[i].String(), cyan, green)
+	}
+	return str +
+		joinStrCol("LOS [3]", l.Los.List(), cyan, red) +
+		joinStrCol("Fault [4]", l.Fault.List(), cyan, red) +
+		joinStrCol("CDR LOL [5]", l.Lol.List(), cyan, red) +
+		joinStrCol("Temperature Flags [6]", l.TempFlags.List(), cyan, yellow) +
+		joinStrCol("Supply Voltage Flags [7]", l.VccFlags.List(), cyan, yellow) +
+		joinStrCol("RX Power Flags [9-10]", l.RxPowerFlags.List(), cyan, yellow) +
+		joinStrCol("TX Bias Flags [11-12]", l.TxBiasFlags.List(), cyan, yellow) +
+		joinStrCol("TX Power Flags [13-14]", l.TxPowerFlags.List(), cyan, yellow)
+}
@@ -1,11 +1,14 @@
 package sff8636
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"unsafe"
 
 	"github.com/mickep76/go-sff/common"
+	"github.com/mickep76/go-sff/vendors"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,37 +23,37 @@ const (
 )
 
 type Sff8636 struct {
-	Identifier        common.Identifier   `json:"identifier"`     // 128 - Identifier
-	ExtIdentifier     ExtIdentifier       `json:"extIdentifier"`  // 129 - Ext. Identifier
-	Connector         common.Connector    `json:"connector"`      // 130 - Connector Type
-	Transceiver       Transceiver         `json:"transceiver"`    // 131-138 - Specification Compliance
-	Encoding          Encoding            `json:"encoding"`       // 139 - Encoding
-	BrNominal         common.Value100Mbps `json:"brNominal"`      // 140 - BR, nominal
-	RateIdentifier    byte                `json:"rateIdentifier"` // 141 - Extended Rate Select Compliance
-	LengthSmf         common.ValueKm      `json:"lengthSmf"`      // 142 - Length (SMF)
-	LengthOm3         common.ValueM       `json:"lengthOm3"`      // 143 - Length (OM3 50 um)
-	LengthOm2         common.ValueM       `json:"lengthOm2"`      // 144 - Length (OM2 50 um)
-	LengthOm1         common.ValueM       `json:"lengthOm1"`      // 145 - Length (OM1 62.5 um) or Copper Cable Attenuation
-	LengthCopper      common.ValueM       `json:"lengthCopper"`   // 146 - Length (passive copper or active cable or OM4 50 um)
-	DevTech           byte                `json:"-"`              // 147 - Device technology
-	Vendor            common.String16     `json:"vendor"`         // 148-163 - Vendor name
-	ExtModule         byte                `json:"-"`              // 164 - Extended Module
-	VendorOui         common.VendorOUI    `json:"vendorOui"`      // 165-167 - Vendor OUI
-	VendorPn          common.String16     `json:"vendorPn"`       // 168-183 - Vendor PN
-	VendorRev         common.String2      `json:"vendorRev"`      // 184-185 - Vendor rev
-	LaserWavelen      [2]byte             `json:"-"`              // 186 - Wavelength or Copper Cable Attenuation
-	LaserWavelenToler [2]byte             `json:"-"`              // 187 - Wavelength tolerance or Copper Cable Attenuation
-	MaxCaseTempC      byte                `json:"-"`              // 190 - Max case temp.
-	CcBase            byte                `json:"-"`              // 191 - CC_BASE
-	LinkCodes         LinkCodes           `json:"linkCodes"`      // 192 - Link codes
-	Options           [3]byte             `json:"options"`        // 193-195 - Options
-	VendorSn          common.String16     `json:"vendorSn"`       // 196-211 - Vendor SN
-	DateCode          common.DateCode     `json:"dateCode"`       // 212-219 - Date Code
-	DiagMonType       byte                `json:"-"`              // 220 - Diagnostic Monitoring Type
-	EnhOptions        byte                `json:"-"`              // 221 - Enhanced Options
-	BrNominalExt      byte                `json:"-"`              // 222 - BR, Nominal
-	CcExt             byte                `json:"-"`              // 223 - CC_EXT
-	VendorSpec        [32]byte            `json:"-"`              // 224-255 - Vendor Specific
+	Identifier        common.Identifier    `json:"identifier"`        // 128 - Identifier
+	ExtIdentifier     ExtIdentifier        `json:"extIdentifier"`     // 129 - Ext. Identifier
+	Connector         common.Connector     `json:"connector"`         // 130 - Connector Type
+	Transceiver       Transceiver          `json:"transceiver"`       // 131-138 - Specification Compliance
+	Encoding          Encoding             `json:"encoding"`          // 139 - Encoding
+	BrNominal         common.Value100Mbps  `json:"brNominal"`         // 140 - BR, nominal
+	RateIdentifier    RateIdentifier       `json:"rateIdentifier"`    // 141 - Extended Rate Select Compliance
+	LengthSmf         common.ValueKm       `json:"lengthSmf"`         // 142 - Length (SMF)
+	LengthOm3         common.Value2m       `json:"lengthOm3"`         // 143 - Length (OM3 50 um)
+	LengthOm2         common.ValueM        `json:"lengthOm2"`         // 144 - Length (OM2 50 um)
+	LengthOm1         common.ValueM        `json:"lengthOm1"`         // 145 - Length (OM1 62.5 um) or Copper Cable Attenuation
+	LengthCopper      common.ValueM        `json:"lengthCopper"`      // 146 - Length (passive copper or active cable or OM4 50 um)
+	DevTech           DevTech              `json:"devTech"`           // 147 - Device technology
+	Vendor            common.String16      `json:"vendor"`            // 148-163 - Vendor name
+	ExtModule         ExtModule            `json:"extModule"`         // 164 - Extended Module
+	VendorOui         common.VendorOUI     `json:"vendorOui"`         // 165-167 - Vendor OUI
+	VendorPn          common.String16      `json:"vendorPn"`          // 168-183 - Vendor PN
+	VendorRev         common.String2       `json:"vendorRev"`         // 184-185 - Vendor rev
+	LaserWavelen      Wavelength           `json:"laserWavelen"`      // 186-187 - Wavelength or Copper Cable Attenuation
+	LaserWavelenToler WavelengthTolerance  `json:"laserWavelenToler"` // 188-189 - Wavelength tolerance or Copper Cable Attenuation
+	MaxCaseTempC      MaxCaseTemp          `json:"maxCaseTempC"`      // 190 - Max case temp.
+	CcBase            common.Checksum      `json:"ccBase"`            // 191 - CC_BASE
+	LinkCodes         common.ExtCompliance `json:"linkCodes"`         // 192 - Extended Specification Compliance Codes
+	Options           Options              `json:"options"`           // 193-195 - Options
+	VendorSn          common.String16      `json:"vendorSn"`          // 196-211 - Vendor SN
+	DateCode          common.DateCode      `json:"dateCode"`          // 212-219 - Date Code
+	DiagMonType       DiagMonType          `json:"diagMonType"`       // 220 - Diagnostic Monitoring Type
+	EnhOptions        EnhOptions           `json:"enhOptions"`        // 221 - Enhanced Options
+	BrNominalExt      common.Value250Mbps  `json:"brNominalExt"`      // 222 - BR, Nominal
+	CcExt             common.Checksum      `json:"ccExt"`             // 223 - CC_EXT
+	VendorSpec        common.VendorSpec    `json:"vendorSpec"`        // 224-255 - Vendor Specific
 }
 
 func Decode(eeprom []byte) (*Sff8636, error) {
@@ -65,27 +68,123 @@ func Decode(eeprom []byte) (*Sff8636, error) {
 	return nil, fmt.Errorf("unknown eeprom standard, identifier: 0x%02x", byte(eeprom[0]))
 }
 
+type sff8636 Sff8636
+
+func (s *Sff8636) MarshalJSON() ([]byte, error) {
+	w, t := s.wavelengthJSON()
+	return json.Marshal(struct {
+		sff8636
+		BrNominal         interface{}     `json:"brNominal"`
+		LaserWavelen      interface{}     `json:"laserWavelen"`
+		LaserWavelenToler interface{}     `json:"laserWavelenToler"`
+		LengthCopper      common.Length   `json:"lengthCopper"`
+		CableAttenuation  []Attenuation   `json:"cableAttenuation,omitempty"`
+		BrNominalMbps     uint            `json:"brNominalMbps"`
+		PowerClass        uint8           `json:"powerClass"`
+		Reach             common.Reaches  `json:"reach"`
+		RateSelect        []string        `json:"rateSelectControl"`
+		VendorName        string          `json:"vendorName"`
+		VendorFields      []vendors.Field `json:"vendorFields,omitempty"`
+	}{
+		sff8636:           sff8636(*s),
+		BrNominal:         s.brNominalJSON(),
+		LaserWavelen:      w,
+		LaserWavelenToler: t,
+		CableAttenuation:  s.CableAttenuation(),
+		BrNominalMbps:     s.BrNominalMbps(),
+		PowerClass:        s.PowerClass(),
+		Reach:             s.Reach(),
+		RateSelect:        s.RateSelectControl(),
+		VendorName:        s.VendorName(),
+		VendorFields:      s.VendorFields(),
+		LengthCopper:      s.LengthOm4OrCopper(),
+	})
+}
+
+func (s *Sff8636) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(s)
+}
+
+func (s *Sff8636) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, s)
+}
+
+// brNominalJSON returns byte 140 without a value when the bit rate is in byte 222, see
+// BrNominalMbps.
+func (s *Sff8636) brNominalJSON() interface{} {
+	if s.BrNominal == 0xFF && s.BrNominalExt != 0 {
+		return map[string]string{"hex": "ff"}
+	}
+	return s.BrNominal
+}
+
+func (s *Sff8636) brNominalKey() string {
+	if s.BrNominal == 0xFF && s.BrNominalExt != 0 {
+		return "BR, Nominal [222]"
+	}
+	return "BR, Nominal [140]"
+}
+
+func (s *Sff8636) maxPowerString() string {
+	if s.PowerClass() == 8 {
+		return "max. power consumption in byte 107"
+	}
+	return fmt.Sprintf("%s max. power consumption", s.ExtIdentifier.MaxPower())
+}
+
 func (s *Sff8636) String() string {
-	return fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Identifier [128]", byte(s.Identifier), s.Identifier) +
+	str := fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Identifier [128]", byte(s.Identifier), s.Identifier) +
 		fmt.Sprintf("%-50s : 0x%02x\n", "Extended Identifier [129]", byte(s.ExtIdentifier)) +
 		fmt.Sprintf("%-50s : %s\n", "Extended Identifier Description", strings.Join(s.ExtIdentifier.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %d (%s)\n", "Power Class", s.PowerClass(), s.maxPowerString()) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Connector [130]", byte(s.Connector), s.Connector) +
 		fmt.Sprintf("%-50s : 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x\n", "Transceiver Codes [131-138]", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]) +
 		fmt.Sprintf("%-50s : %s\n", "Transceiver Type", strings.Join(s.Transceiver.List(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Encoding [139]", byte(s.Encoding), s.Encoding) +
-		fmt.Sprintf("%-50s : %s\n", "BR, Nominal [140]", s.BrNominal) +
-		fmt.Sprintf("%-50s : 0x%02x\n", "Rate Identifier [141]", s.RateIdentifier) +
+		fmt.Sprintf("%-50s : %d Mb/s\n", s.brNominalKey(), s.BrNominalMbps()) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Rate Identifier [141]", byte(s.RateIdentifier), s.RateIdentifier) +
+		fmt.Sprintf("%-50s : %s\n", "Rate Select Rates", strings.Join(s.RateIdentifier.Rates(), ", ")) +
+		fmt.Sprintf("%-50s : %s\n", "Rate Select Control", strings.Join(s.RateSelectControl(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "Length (SMF) [142]", s.LengthSmf) +
 		fmt.Sprintf("%-50s : %s\n", "Length (OM3 50um) [143]", s.LengthOm3) +
 		fmt.Sprintf("%-50s : %s\n", "Length (OM2 50um) [144]", s.LengthOm2) +
-		fmt.Sprintf("%-50s : %s\n", "Length (OM1 62.5um) [145]", s.LengthOm1) +
-		fmt.Sprintf("%-50s : %s\n", "Length (Copper or Active cable) [146]", s.LengthCopper) +
+		fmt.Sprintf("%-50s : %s\n", s.lengthOm1Key(), s.lengthOm1String()) +
+		fmt.Sprintf("%-50s : %s\n", s.lengthOm4OrCopperKey(), s.LengthOm4OrCopper()) +
+		fmt.Sprintf("%-50s : %s\n", "Reach", strings.Join(s.Reach().List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Device Technology [147]", strings.Join(s.DevTech.List(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor [148-163]", s.Vendor) +
-		fmt.Sprintf("%-50s : %s\n", "Vendor OUI [165-167]", s.VendorOui) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor Name", s.VendorName()) +
+		fmt.Sprintf("%-50s : %s\n", "Extended Module Codes [164]", strings.Join(s.ExtModule.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor OUI [165-167]", ouiString(s.VendorOui)) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor PN [168-183]", s.VendorPn) +
-		fmt.Sprintf("%-50s : %s\n", "Vendor Rev [184-185]", s.VendorRev) +
+		fmt.Sprintf("%-50s : %s\n", "Vendor Rev [184-185]", s.VendorRev)
+
+	if s.Copper() {
+		for i, a := range s.CableAttenuation() {
+			if attenuationBytes[i] == 145 {
+				continue
+			}
+			str += fmt.Sprintf("%-50s : %d dB\n", fmt.Sprintf("Attenuation at %g GHz [%d]", a.Frequency, attenuationBytes[i]), a.Value)
+		}
+	} else {
+		str += fmt.Sprintf("%-50s : %s\n", "Laser Wavelength [186-187]", s.LaserWavelen) +
+			fmt.Sprintf("%-50s : %s\n", "Laser Wavelength Tolerance [188-189]", s.LaserWavelenToler)
+	}
+
+	str += fmt.Sprintf("%-50s : %s\n", "Max Case Temperature [190]", s.MaxCaseTempC) +
+		fmt.Sprintf("%-50s : 0x%02x (%s)\n", "Extended Compliance [192]", byte(s.LinkCodes), s.LinkCodes) +
+		fmt.Sprintf("%-50s : %s\n", "Options [193-195]", strings.Join(s.Options.List(), fmt.Sprintf("\n%-50s : ", " "))) +
 		fmt.Sprintf("%-50s : %s\n", "Vendor SN [196-211]", s.VendorSn) +
-		fmt.Sprintf("%-50s : %s\n", "Date Code [212-219]", s.DateCode)
+		fmt.Sprintf("%-50s : %s\n", "Date Code [212-219]", s.DateCode) +
+		fmt.Sprintf("%-50s : %s\n", "Lot Code [218-219]", s.DateCode.Lot()) +
+		fmt.Sprintf("%-50s : %s\n", "Diagnostic Monitoring Type [220]", strings.Join(s.DiagMonType.List(), fmt.Sprintf("\n%-50s : ", " "))) +
+		fmt.Sprintf("%-50s : %s\n", "Enhanced Options [221]", strings.Join(s.EnhOptions.List(), fmt.Sprintf("\n%-50s : ", " ")))
+
+	for _, f := range s.VendorFields() {
+		str += fmt.Sprintf("%-50s : %s\n", f.Key(), f.Value)
+	}
+
+	return str
 }
 
 func strCol(k string, v string, c1 string, c2 string) string {
@@ -93,6 +192,10 @@ func strCol(k string, v string, c1 string, c2 string) string {
 }
 
 func joinStrCol(k string, l []string, c1 string, c2 string) string {
+	if len(l) < 1 {
+		return ""
+	}
+
 	r := strCol(k, l[0], c1, c2)
 	for _, s := range l[1:] {
 		r += strCol("", s, c1, c2)
@@ -101,24 +204,56 @@ func joinStrCol(k string, l []string, c1 string, c2 string) string {
 }
 
 func (s *Sff8636) StringCol() string {
-	return strCol("Identifier [128]", fmt.Sprintf("0x%02x (%s)", byte(s.Identifier), s.Identifier), cyan, green) +
+	str := strCol("Identifier [128]", fmt.Sprintf("0x%02x (%s)", byte(s.Identifier), s.Identifier), cyan, green) +
 		strCol("Extended Identifier [129]", fmt.Sprintf("0x%02x", byte(s.ExtIdentifier)), cyan, green) +
 		strCol("Extended Identifier Description", strings.Join(s.ExtIdentifier.List(), fmt.Sprintf("\n%-50s : ", " ")), cyan, green) +
+		strCol("Power Class", fmt.Sprintf("%d (%s)", s.PowerClass(), s.maxPowerString()), cyan, green) +
 		strCol("Connector [130]", fmt.Sprintf("0x%02x (%s)", byte(s.Connector), s.Connector), cyan, green) +
 		strCol("Transceiver Codes [131-138]", fmt.Sprintf("0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x 0x%02x", s.Transceiver[0], s.Transceiver[1], s.Transceiver[2], s.Transceiver[3], s.Transceiver[4], s.Transceiver[5], s.Transceiver[6], s.Transceiver[7]), cyan, green) +
 		joinStrCol("Transceiver Type", s.Transceiver.List(), cyan, yellow) +
 		strCol("Encoding [139]", fmt.Sprintf("0x%02x (%s)", byte(s.Encoding), s.Encoding), cyan, green) +
-		strCol("BR, Nominal [140]", s.BrNominal.String(), cyan, green) +
-		strCol("Rate Identifier [141]", fmt.Sprintf("0x%02x", s.RateIdentifier), cyan, green) +
+		strCol(s.brNominalKey(), fmt.Sprintf("%d Mb/s", s.BrNominalMbps()), cyan, green) +
+		strCol("Rate Identifier [141]", fmt.Sprintf("0x%02x (%s)", byte(s.RateIdentifier), s.RateIdentifier), cyan, green) +
+		strCol("Rate Select Rates", strings.Join(s.RateIdentifier.Rates(), ", "), cyan, green) +
+		joinStrCol("Rate Select Control", s.RateSelectControl(), cyan, yellow) +
 		strCol("Length (SMF) [142]", s.LengthSmf.String(), cyan, green) +
 		strCol("Length (OM3 50um) [143]", s.LengthOm3.String(), cyan, green) +
 		strCol("Length (OM2 50um) [144]", s.LengthOm2.String(), cyan, green) +
-		strCol("Length (OM1 62.5um) [145]", s.LengthOm1.String(), cyan, green) +
-		strCol("Length (Copper or Active cable) [146]", s.LengthCopper.String(), cyan, green) +
+		strCol(s.lengthOm1Key(), s.lengthOm1String(), cyan, green) +
+		strCol(s.lengthOm4OrCopperKey(), s.LengthOm4OrCopper().String(), cyan, green) +
+		joinStrCol("Reach", s.Reach().List(), cyan, yellow) +
+		joinStrCol("Device Technology [147]", s.DevTech.List(), cyan, yellow) +
 		strCol("Vendor [148-163]", s.Vendor.String(), cyan, green) +
-		strCol("Vendor OUI [165-167]", s.VendorOui.String(), cyan, green) +
+		strCol("Vendor Name", s.VendorName(), cyan, green) +
+		joinStrCol("Extended Module Codes [164]", s.ExtModule.List(), cyan, yellow) +
+		strCol("Vendor OUI [165-167]", ouiString(s.VendorOui), cyan, green) +
 		strCol("Vendor PN [168-183]", s.VendorPn.String(), cyan, green) +
-		strCol("Vendor Rev [184-185]", s.VendorRev.String(), cyan, green) +
+		strCol("Vendor Rev [184-185]", s.VendorRev.String(), cyan, green)
+
+	if s.Copper() {
+		for i, a := range s.CableAttenuation() {
+			if attenuationBytes[i] == 145 {
+				continue
+			}
+			str += strCol(fmt.Sprintf("Attenuation at %g GHz [%d]", a.Frequency, attenuationBytes[i]), fmt.Sprintf("%d dB", a.Value), cyan, green)
+		}
+	} else {
+		str += strCol("Laser Wavelength [186-187]", s.LaserWavelen.String(), cyan, green) +
+			strCol("Laser Wavelength Tolerance [188-189]", s.LaserWavelenToler.String(), cyan, green)
+	}
+
+	str += strCol("Max Case Temperature [190]", s.MaxCaseTempC.String(), cyan, green) +
+		strCol("Extended Compliance [192]", fmt.Sprintf("0x%02x (%s)", byte(s.LinkCodes), s.LinkCodes), cyan, green) +
+		joinStrCol("Options [193-195]", s.Options.List(), cyan, yellow) +
 		strCol("Vendor SN [196-211]", s.VendorSn.String(), cyan, green) +
-		strCol("Date Code [212-219]", s.DateCode.String(), cyan, green)
+		strCol("Date Code [212-219]", s.DateCode.String(), cyan, green) +
+		strCol("Lot Code [218-219]", s.DateCode.Lot(), cyan, green) +
+		joinStrCol("Diagnostic Monitoring Type [220]", s.DiagMonType.List(), cyan, yellow) +
+		joinStrCol("Enhanced Options [221]", s.EnhOptions.List(), cyan, yellow)
+
+	for _, f := range s.VendorFields() {
+		str += strCol(f.Key(), f.Value, cyan, green)
+	}
+
+	return str
 }
@@ -0,0 +1,237 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Option bits, byte 193 in bits 16-23, byte 194 in bits 8-15 and byte 195 in bits 0-7.
+const (
+	OptPages20h21h       = (1 << 0)
+	OptTxLos             = (1 << 1)
+	OptTxSquelchPave     = (1 << 2)
+	OptTxFault           = (1 << 3)
+	OptTxDisable         = (1 << 4)
+	OptRateSelect        = (1 << 5)
+	OptPage01h           = (1 << 6)
+	OptPage02h           = (1 << 7)
+	OptTxSquelch         = (1 << 8)
+	OptTxSquelchDisable  = (1 << 9)
+	OptRxOutputDisable   = (1 << 10)
+	OptRxSquelchDisable  = (1 << 11)
+	OptRxCdrLol          = (1 << 12)
+	OptTxCdrLol          = (1 << 13)
+	OptRxCdrControl      = (1 << 14)
+	OptTxCdrControl      = (1 << 15)
+	OptRxAmplitudeProg   = (1 << 16)
+	OptRxEmphasisProg    = (1 << 17)
+	OptTxEqProg          = (1 << 18)
+	OptTxEqAutoAdaptive  = (1 << 19)
+	OptTxEqFreeze        = (1 << 20)
+	OptIntLRxLoslConfig  = (1 << 21)
+	OptLpModeTxDisConfig = (1 << 22)
+)
+
+var optionNames = map[uint32]string{
+	OptPages20h21h:       "Pages 20h-21h provided",
+	OptTxLos:             "TX LOS implemented",
+	OptTxSquelchPave:     "TX squelch implemented to reduce Pave",
+	OptTxFault:           "TX_FAULT implemented",
+	OptTxDisable:         "TX_DISABLE implemented",
+	OptRateSelect:        "RATE_SELECT implemented",
+	OptPage01h:           "Page 01h provided",
+	OptPage02h:           "Page 02h provided",
+	OptTxSquelch:         "TX squelch implemented",
+	OptTxSquelchDisable:  "TX squelch disable implemented",
+	OptRxOutputDisable:   "RX output disable implemented",
+	OptRxSquelchDisable:  "RX squelch disable implemented",
+	OptRxCdrLol:          "RX CDR loss of lock flag implemented",
+	OptTxCdrLol:          "TX CDR loss of lock flag implemented",
+	OptRxCdrControl:      "RX CDR on/off control implemented",
+	OptTxCdrControl:      "TX CDR on/off control implemented",
+	OptRxAmplitudeProg:   "RX output amplitude programmable",
+	OptRxEmphasisProg:    "RX output emphasis programmable",
+	OptTxEqProg:          "TX input equalization programmable",
+	OptTxEqAutoAdaptive:  "TX input equalization auto-adaptive",
+	OptTxEqFreeze:        "TX input adaptive equalization freeze capable",
+	OptIntLRxLoslConfig:  "IntL/RxLOSL output configurable",
+	OptLpModeTxDisConfig: "LPMode/TxDis input configurable",
+}
+
+// Options are the implemented option bits, bytes 193-195.
+type Options [3]byte
+
+func (o Options) Uint32() uint32 {
+	return uint32(o[0])<<16 | uint32(o[1])<<8 | uint32(o[2])
+}
+
+// Has returns true if all option bits are set, see OptTxDisable etc.
+func (o Options) Has(opt uint32) bool {
+	return o.Uint32()&opt == opt
+}
+
+func (o Options) List() []string {
+	r := []string{}
+	for i := uint(0); i < 24; i++ {
+		if n, ok := optionNames[1<<i]; ok && o.Has(1<<i) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (o Options) String() string {
+	return strings.Join(o.List(), "\n")
+}
+
+func (o Options) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": o.List(),
+		"hex":    hex.EncodeToString(o[:3]),
+	}
+	return json.Marshal(m)
+}
+
+func (o *Options) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 3 {
+		return fmt.Errorf("length is shorter then Options type")
+	}
+
+	*o = Options{b[0], b[1], b[2]}
+	return nil
+}
+
+const (
+	DiagTxPower      = (1 << 2)
+	DiagRxPwrAverage = (1 << 3)
+	DiagVcc          = (1 << 4)
+	DiagTemp         = (1 << 5)
+)
+
+var diagMonTypeNames = map[byte]string{
+	DiagTxPower:      "TX power measurement supported",
+	DiagRxPwrAverage: "Received power measurement type: Average power",
+	DiagVcc:          "Supply voltage monitoring implemented",
+	DiagTemp:         "Temperature monitoring implemented",
+}
+
+// DiagMonType is the diagnostic monitoring type, byte 220.
+type DiagMonType byte
+
+// Has returns true if all bits are set, see DiagTemp etc.
+func (d DiagMonType) Has(opt byte) bool {
+	return byte(d)&opt == opt
+}
+
+func (d DiagMonType) List() []string {
+	r := []string{}
+	for i := 7; i >= 0; i-- {
+		if n, ok := diagMonTypeNames[1<<uint(i)]; ok && d.Has(1<<uint(i)) {
+			r = append(r, n)
+		} else if 1<<uint(i) == DiagRxPwrAverage {
+			r = append(r, "Received power measurement type: OMA")
+		}
+	}
+	return r
+}
+
+func (d DiagMonType) String() string {
+	return strings.Join(d.List(), "\n")
+}
+
+func (d DiagMonType) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": d.List(),
+		"hex":    hex.EncodeToString([]byte{byte(d)}),
+	}
+	return json.Marshal(m)
+}
+
+func (d *DiagMonType) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*d = DiagMonType(b[0])
+	return nil
+}
+
+const (
+	EnhTcReadiness    = (1 << 1)
+	EnhAppSelectTable = (1 << 2)
+	EnhRateSelect     = (1 << 3)
+	EnhInitComplete   = (1 << 4)
+)
+
+var enhOptionsNames = map[byte]string{
+	EnhTcReadiness:    "TC readiness flag implemented",
+	EnhAppSelectTable: "Application select table supported",
+	EnhRateSelect:     "Rate select supported",
+	EnhInitComplete:   "Initialization complete flag implemented",
+}
+
+// EnhOptions are the enhanced options, byte 221.
+type EnhOptions byte
+
+// Has returns true if all bits are set, see EnhRateSelect etc.
+func (e EnhOptions) Has(opt byte) bool {
+	return byte(e)&opt == opt
+}
+
+func (e EnhOptions) List() []string {
+	r := []string{}
+	for i := uint(0); i < 8; i++ {
+		if n, ok := enhOptionsNames[1<<i]; ok && e.Has(1<<i) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func (e EnhOptions) String() string {
+	return strings.Join(e.List(), "\n")
+}
+
+func (e EnhOptions) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"values": e.List(),
+		"hex":    hex.EncodeToString([]byte{byte(e)}),
+	}
+	return json.Marshal(m)
+}
+
+func (e *EnhOptions) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*e = EnhOptions(b[0])
+	return nil
+}
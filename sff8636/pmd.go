@@ -0,0 +1,84 @@
+package sff8636
+
+import (
+	"sort"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+var extCompliancePmds = map[byte]string{
+	common.ExtCompliance100gAoc5e5:     "100G AOC",
+	common.ExtCompliance100gSr4:        "100GBASE-SR4",
+	common.ExtCompliance100gLr4:        "100GBASE-LR4",
+	common.ExtCompliance100gEr4:        "100GBASE-ER4",
+	common.ExtCompliance100gSr10:       "100GBASE-SR10",
+	common.ExtCompliance100gCwdm4:      "100G CWDM4",
+	common.ExtCompliance100gPsm4:       "100G PSM4",
+	common.ExtCompliance100gAcc5e5:     "100G ACC",
+	common.ExtCompliance100gCwdm4NoFec: "100G CWDM4",
+	common.ExtCompliance100gCr4:        "100GBASE-CR4",
+	common.ExtCompliance40gEr4:         "40GBASE-ER4",
+	common.ExtCompliance4x10gSr:        "4x10GBASE-SR",
+	common.ExtCompliance40gPsm4:        "40G PSM4",
+	common.ExtCompliance100gClr4:       "100G CLR4",
+	common.ExtCompliance100gAoc1e12:    "100G AOC",
+	common.ExtCompliance100gAcc1e12:    "100G ACC",
+	common.ExtCompliance40gSwdm4:       "40G SWDM4",
+	common.ExtCompliance100gSwdm4:      "100G SWDM4",
+	common.ExtCompliance100gDr:         "100GBASE-DR",
+	common.ExtCompliance100gFr:         "100GBASE-FR1",
+	common.ExtCompliance100gLr:         "100GBASE-LR1",
+	common.ExtCompliance100gFr1:        "100GBASE-FR1",
+	common.ExtCompliance100gLr1:        "100GBASE-LR1",
+	common.ExtCompliance200gFr4:        "200GBASE-FR4",
+	common.ExtCompliance200gLr4:        "200GBASE-LR4",
+	common.ExtCompliance400gSr4:        "400GBASE-SR4",
+	common.ExtCompliance400gDr4:        "400GBASE-DR4",
+	common.ExtCompliance400gFr4:        "400GBASE-FR4",
+}
+
+var transceiverPmds = []struct {
+	code uint64
+	pmd  string
+}{
+	{Ethernet40gSr4, "40GBASE-SR4"},
+	{Ethernet40gLr4, "40GBASE-LR4"},
+	{Ethernet40gCr4, "40GBASE-CR4"},
+	{Ethernet10gSr, "10GBASE-SR"},
+	{Ethernet10gLr, "10GBASE-LR"},
+	{Ethernet10gLrm, "10GBASE-LRM"},
+}
+
+// Pmd returns the physical medium dependent type, such as 100GBASE-SR4, derived from the
+// compliance codes. An empty string is returned if it can't be determined.
+func (s *Sff8636) Pmd() string {
+	if p, ok := extCompliancePmds[byte(s.LinkCodes)]; ok {
+		return p
+	}
+
+	t := s.Transceiver.Uint64()
+	for _, p := range transceiverPmds {
+		if t&p.code != 0 {
+			return p.pmd
+		}
+	}
+	return ""
+}
+
+// Pmds returns the PMD types Pmd can return, sorted.
+func Pmds() []string {
+	seen := map[string]bool{}
+	for _, p := range extCompliancePmds {
+		seen[p] = true
+	}
+	for _, p := range transceiverPmds {
+		seen[p.pmd] = true
+	}
+
+	l := []string{}
+	for p := range seen {
+		l = append(l, p)
+	}
+	sort.Strings(l)
+	return l
+}
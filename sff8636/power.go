@@ -0,0 +1,70 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+// MaxPower is the max power consumption for power class 8 in units of 0.1 W, byte 107.
+type MaxPower byte
+
+func (p MaxPower) Watts() common.Watts {
+	return common.Watts(float64(p) / 10)
+}
+
+func (p MaxPower) String() string {
+	return p.Watts().String()
+}
+
+func (p MaxPower) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": float64(p.Watts()),
+		"unit":  "W",
+		"hex":   hex.EncodeToString([]byte{byte(p)}),
+	}
+	return json.Marshal(m)
+}
+
+func (p *MaxPower) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*p = MaxPower(b[0])
+	return nil
+}
+
+// PowerClass returns the power class 1-8.
+func (s *Sff8636) PowerClass() uint8 {
+	return s.ExtIdentifier.PowerClass()
+}
+
+// MaxPowerW returns the max power consumption, for power class 8 it's read from byte 107
+// in the lower page which may be nil if not available.
+func (s *Sff8636) MaxPowerW(l *LowerPage) common.Watts {
+	if s.PowerClass() == 8 {
+		if l == nil {
+			return 0
+		}
+		return l.MaxPower.Watts()
+	}
+	return s.ExtIdentifier.MaxPower()
+}
+
+// BrNominalMbps returns the nominal bit rate in Mb/s, rates above 25.4 Gb/s
+// are in byte 222 in units of 250 Mb/s when byte 140 is 0xFF.
+func (s *Sff8636) BrNominalMbps() uint {
+	if s.BrNominal == 0xFF && s.BrNominalExt != 0 {
+		return uint(s.BrNominalExt) * 250
+	}
+	return uint(s.BrNominal) * 100
+}
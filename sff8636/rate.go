@@ -0,0 +1,90 @@
+package sff8636
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+const (
+	RateSelectVersionMask = 0x03
+	RateSelectVersion1    = 0x01
+	RateSelectVersion2    = 0x02
+)
+
+var rateIdentifierNames = map[byte]string{
+	RateSelectVersion1: "Extended rate select version 1",
+	RateSelectVersion2: "Extended rate select version 2",
+}
+
+var rateIdentifierRates = map[byte][]string{
+	RateSelectVersion1: {"6.6 Gb/s and higher", "2.2 to 6.6 Gb/s", "less than 2.2 Gb/s"},
+	RateSelectVersion2: {"more than 26 Gb/s", "24 to 26 Gb/s", "12 to 24 Gb/s", "12 Gb/s or less"},
+}
+
+// RateIdentifier is the extended rate select compliance, byte 141.
+type RateIdentifier byte
+
+// Version returns the extended rate select version, see RateSelectVersion1 etc.
+func (r RateIdentifier) Version() byte {
+	return byte(r) & RateSelectVersionMask
+}
+
+func (r RateIdentifier) String() string {
+	n, ok := rateIdentifierNames[r.Version()]
+	if !ok {
+		return "Unspecified"
+	}
+	return n
+}
+
+// Rates returns the rates selectable with extended rate select, nil if none are defined.
+func (r RateIdentifier) Rates() []string {
+	return rateIdentifierRates[r.Version()]
+}
+
+func (r RateIdentifier) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": r.String(),
+		"hex":   hex.EncodeToString([]byte{byte(r)}),
+	}
+	if l := r.Rates(); l != nil {
+		m["rates"] = l
+	}
+	return json.Marshal(m)
+}
+
+func (r *RateIdentifier) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	*r = RateIdentifier(b[0])
+	return nil
+}
+
+const (
+	RateSelectExtended  = "Soft, extended rate select (bytes 87-88)"
+	RateSelectAppSelect = "Soft, application select table (bytes 89-94)"
+)
+
+// RateSelectControl returns how rate select is controlled, see RateSelectExtended etc.
+func (s *Sff8636) RateSelectControl() []string {
+	r := []string{}
+	if !s.Options.Has(OptRateSelect) {
+		return r
+	}
+	if s.EnhOptions.Has(EnhRateSelect) {
+		r = append(r, RateSelectExtended)
+	}
+	if s.EnhOptions.Has(EnhAppSelectTable) {
+		r = append(r, RateSelectAppSelect)
+	}
+	return r
+}
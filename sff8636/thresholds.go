@@ -0,0 +1,80 @@
+package sff8636
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/mickep76/go-sff/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Thresholds is the upper memory page 03h, bytes 128-255.
+type Thresholds struct {
+	TempHighAlarm    common.Temperature `json:"tempHighAlarm"`    // 128-129 - Temp High Alarm
+	TempLowAlarm     common.Temperature `json:"tempLowAlarm"`     // 130-131 - Temp Low Alarm
+	TempHighWarning  common.Temperature `json:"tempHighWarning"`  // 132-133 - Temp High Warning
+	TempLowWarning   common.Temperature `json:"tempLowWarning"`   // 134-135 - Temp Low Warning
+	Reserved1        [8]byte            `json:"-"`                // 136-143 - Reserved
+	VccHighAlarm     common.Voltage     `json:"vccHighAlarm"`     // 144-145 - Vcc High Alarm
+	VccLowAlarm      common.Voltage     `json:"vccLowAlarm"`      // 146-147 - Vcc Low Alarm
+	VccHighWarning   common.Voltage     `json:"vccHighWarning"`   // 148-149 - Vcc High Warning
+	VccLowWarning    common.Voltage     `json:"vccLowWarning"`    // 150-151 - Vcc Low Warning
+	Reserved2        [8]byte            `json:"-"`                // 152-159 - Reserved
+	VendorSpec1      [16]byte           `json:"-"`                // 160-175 - Vendor Specific
+	RxPwrHighAlarm   common.Power       `json:"rxPwrHighAlarm"`   // 176-177 - RX Power High Alarm
+	RxPwrLowAlarm    common.Power       `json:"rxPwrLowAlarm"`    // 178-179 - RX Power Low Alarm
+	RxPwrHighWarning common.Power       `json:"rxPwrHighWarning"` // 180-181 - RX Power High Warning
+	RxPwrLowWarning  common.Power       `json:"rxPwrLowWarning"`  // 182-183 - RX Power Low Warning
+	BiasHighAlarm    common.Current     `json:"biasHighAlarm"`    // 184-185 - TX Bias High Alarm
+	BiasLowAlarm     common.Current     `json:"biasLowAlarm"`     // 186-187 - TX Bias Low Alarm
+	BiasHighWarning  common.Current     `json:"biasHighWarning"`  // 188-189 - TX Bias High Warning
+	BiasLowWarning   common.Current     `json:"biasLowWarning"`   // 190-191 - TX Bias Low Warning
+	TxPwrHighAlarm   common.Power       `json:"txPwrHighAlarm"`   // 192-193 - TX Power High Alarm
+	TxPwrLowAlarm    common.Power       `json:"txPwrLowAlarm"`    // 194-195 - TX Power Low Alarm
+	TxPwrHighWarning common.Power       `json:"txPwrHighWarning"` // 196-197 - TX Power High Warning
+	TxPwrLowWarning  common.Power       `json:"txPwrLowWarning"`  // 198-199 - TX Power Low Warning
+	Reserved3        [56]byte           `json:"-"`                // 200-255 - Reserved, Channel Controls and Masks
+}
+
+// DecodeThresholds decodes page 03h, eeprom should start at page 03h byte 128.
+func DecodeThresholds(eeprom []byte) (*Thresholds, error) {
+	if len(eeprom) < 128 {
+		return nil, fmt.Errorf("eeprom size to small needs to be 128 bytes or larger got: %d bytes", len(eeprom))
+	}
+
+	return (*Thresholds)(unsafe.Pointer(&eeprom[0])), nil
+}
+
+func (t *Thresholds) MarshalYAML() (interface{}, error) {
+	return common.MarshalYAML(t)
+}
+
+func (t *Thresholds) UnmarshalYAML(n *yaml.Node) error {
+	return common.UnmarshalYAML(n, t)
+}
+
+func (t *Thresholds) String() string {
+	return fmt.Sprintf("%-50s : %s / %s\n", "Temp High Alarm/Warning [128-129/132-133]", t.TempHighAlarm, t.TempHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Temp Low Alarm/Warning [130-131/134-135]", t.TempLowAlarm, t.TempLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Vcc High Alarm/Warning [144-145/148-149]", t.VccHighAlarm, t.VccHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "Vcc Low Alarm/Warning [146-147/150-151]", t.VccLowAlarm, t.VccLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "RX Power High Alarm/Warning [176-177/180-181]", t.RxPwrHighAlarm, t.RxPwrHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "RX Power Low Alarm/Warning [178-179/182-183]", t.RxPwrLowAlarm, t.RxPwrLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Bias High Alarm/Warning [184-185/188-189]", t.BiasHighAlarm, t.BiasHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Bias Low Alarm/Warning [186-187/190-191]", t.BiasLowAlarm, t.BiasLowWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Power High Alarm/Warning [192-193/196-197]", t.TxPwrHighAlarm, t.TxPwrHighWarning) +
+		fmt.Sprintf("%-50s : %s / %s\n", "TX Power Low Alarm/Warning [194-195/198-199]", t.TxPwrLowAlarm, t.TxPwrLowWarning)
+}
+
+func (t *Thresholds) StringCol() string {
+	return strCol("Temp High Alarm/Warning [128-129/132-133]", fmt.Sprintf("%s / %s", t.TempHighAlarm, t.TempHighWarning), cyan, green) +
+		strCol("Temp Low Alarm/Warning [130-131/134-135]", fmt.Sprintf("%s / %s", t.TempLowAlarm, t.TempLowWarning), cyan, green) +
+		strCol("Vcc High Alarm/Warning [144-145/148-149]", fmt.Sprintf("%s / %s", t.VccHighAlarm, t.VccHighWarning), cyan, green) +
+		strCol("Vcc Low Alarm/Warning [146-147/150-151]", fmt.Sprintf("%s / %s", t.VccLowAlarm, t.VccLowWarning), cyan, green) +
+		strCol("RX Power High Alarm/Warning [176-177/180-181]", fmt.Sprintf("%s / %s", t.RxPwrHighAlarm, t.RxPwrHighWarning), cyan, green) +
+		strCol("RX Power Low Alarm/Warning [178-179/182-183]", fmt.Sprintf("%s / %s", t.RxPwrLowAlarm, t.RxPwrLowWarning), cyan, green) +
+		strCol("TX Bias High Alarm/Warning [184-185/188-189]", fmt.Sprintf("%s / %s", t.BiasHighAlarm, t.BiasHighWarning), cyan, green) +
+		strCol("TX Bias Low Alarm/Warning [186-187/190-191]", fmt.Sprintf("%s / %s", t.BiasLowAlarm, t.BiasLowWarning), cyan, green) +
+		strCol("TX Power High Alarm/Warning [192-193/196-197]", fmt.Sprintf("%s / %s", t.TxPwrHighAlarm, t.TxPwrHighWarning), cyan, green) +
+		strCol("TX Power Low Alarm/Warning [194-195/198-199]", fmt.Sprintf("%s / %s", t.TxPwrLowAlarm, t.TxPwrLowWarning), cyan, green)
+}
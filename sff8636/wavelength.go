@@ -0,0 +1,134 @@
+package sff8636
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Wavelength in units of 0.05 nm for optical modules, bytes 186-187.
+type Wavelength [2]byte
+
+// WavelengthTolerance in units of 0.005 nm for optical modules, bytes 188-189.
+type WavelengthTolerance [2]byte
+
+// Nm returns the laser wavelength in nm.
+func (w Wavelength) Nm() float64 {
+	return float64(binary.BigEndian.Uint16(w[:])) / 20
+}
+
+func (w Wavelength) String() string {
+	return fmt.Sprintf("%.2f nm", w.Nm())
+}
+
+func (w Wavelength) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": w.Nm(),
+		"unit":  "nm",
+		"hex":   hex.EncodeToString(w[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (w *Wavelength) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then Wavelength type")
+	}
+
+	*w = Wavelength{b[0], b[1]}
+	return nil
+}
+
+// Nm returns the laser wavelength tolerance in +/- nm.
+func (w WavelengthTolerance) Nm() float64 {
+	return float64(binary.BigEndian.Uint16(w[:])) / 200
+}
+
+func (w WavelengthTolerance) String() string {
+	return fmt.Sprintf("%.3f nm", w.Nm())
+}
+
+func (w WavelengthTolerance) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"value": w.Nm(),
+		"unit":  "nm",
+		"hex":   hex.EncodeToString(w[:2]),
+	}
+	return json.Marshal(m)
+}
+
+func (w *WavelengthTolerance) UnmarshalJSON(in []byte) error {
+	m := map[string]interface{}{}
+	err := json.Unmarshal(in, &m)
+	if err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(m["hex"].(string))
+	if err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return fmt.Errorf("length is shorter then WavelengthTolerance type")
+	}
+
+	*w = WavelengthTolerance{b[0], b[1]}
+	return nil
+}
+
+// attenuationBytes are the offsets of the values returned by CableAttenuation.
+var attenuationBytes = []int{186, 187, 188, 189, 145}
+
+// Attenuation of a copper cable at a frequency.
+type Attenuation struct {
+	Frequency float64 `json:"frequency"`
+	Value     uint8   `json:"value"`
+	Unit      string  `json:"unit"`
+}
+
+func (a Attenuation) String() string {
+	return fmt.Sprintf("%d dB at %g GHz", a.Value, a.Frequency)
+}
+
+// Copper returns true for copper cables, transmitter technology in byte 147 bit 4-7 is 1010b or higher.
+func (s *Sff8636) Copper() bool {
+	return s.DevTech.Copper()
+}
+
+// CableAttenuation returns the copper cable attenuation from byte 145 and 186-189,
+// nil is returned for optical modules.
+func (s *Sff8636) CableAttenuation() []Attenuation {
+	if !s.Copper() {
+		return nil
+	}
+
+	return []Attenuation{
+		{Frequency: 2.5, Value: s.LaserWavelen[0], Unit: "dB"},
+		{Frequency: 5, Value: s.LaserWavelen[1], Unit: "dB"},
+		{Frequency: 7, Value: s.LaserWavelenToler[0], Unit: "dB"},
+		{Frequency: 12.9, Value: s.LaserWavelenToler[1], Unit: "dB"},
+		{Frequency: 25.78, Value: uint8(s.LengthOm1), Unit: "dB"},
+	}
+}
+
+func (s *Sff8636) wavelengthJSON() (interface{}, interface{}) {
+	if !s.Copper() {
+		return s.LaserWavelen, s.LaserWavelenToler
+	}
+
+	return map[string]interface{}{"hex": hex.EncodeToString(s.LaserWavelen[:2])},
+		map[string]interface{}{"hex": hex.EncodeToString(s.LaserWavelenToler[:2])}
+}
@@ -0,0 +1,30 @@
+// Package arista registers a decoder for Arista vendor specific bytes.
+//
+//	import _ "github.com/mickep76/go-sff/vendors/arista"
+package arista
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mickep76/go-sff/vendors"
+)
+
+type decoder struct{}
+
+func init() {
+	vendors.Register("arista", decoder{})
+}
+
+// Match QSFP to SFP breakout cables.
+func (decoder) Match(m *vendors.Module) bool {
+	return m.Vendor == "Arista Networks" && strings.HasPrefix(m.Pn, "CAB-Q-S-")
+}
+
+func (decoder) Decode(m *vendors.Module) []vendors.Field {
+	b := m.Bytes(120, 1)
+	if b == nil {
+		return nil
+	}
+	return []vendors.Field{{Name: "Vendor SA", Offset: 120, Length: 1, Value: fmt.Sprintf("%x", b[0])}}
+}
@@ -0,0 +1,91 @@
+// Package vendors decodes vendor specific EEPROM bytes using registered decoders.
+package vendors
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mickep76/go-sff/common"
+)
+
+// Module is the vendor identification and vendor specific bytes passed to decoders.
+type Module struct {
+	Vendor     string
+	Oui        common.VendorOUI
+	Pn         string
+	Rev        string
+	Sn         string
+	VendorSpec common.VendorSpec
+	Offset     int // Byte offset of VendorSpec, 96 for SFF-8079 and 224 for SFF-8636
+}
+
+// Bytes returns length bytes at the absolute byte offset, nil if outside VendorSpec.
+func (m *Module) Bytes(offset int, length int) []byte {
+	o := offset - m.Offset
+	if o < 0 || length < 0 || o+length > len(m.VendorSpec) {
+		return nil
+	}
+	return m.VendorSpec[o : o+length]
+}
+
+// Field is a decoded vendor specific field.
+type Field struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	Value  string `json:"value"`
+}
+
+// Key returns the name with the byte range, e.g. "Vendor SA [120]".
+func (f Field) Key() string {
+	if f.Length > 1 {
+		return fmt.Sprintf("%s [%d-%d]", f.Name, f.Offset, f.Offset+f.Length-1)
+	}
+	return fmt.Sprintf("%s [%d]", f.Name, f.Offset)
+}
+
+// Decoder decodes the vendor specific bytes for modules it matches.
+type Decoder interface {
+	Match(m *Module) bool
+	Decode(m *Module) []Field
+}
+
+var (
+	mu       sync.RWMutex
+	names    []string
+	decoders = map[string]Decoder{}
+)
+
+// Register a decoder by name, it panics if the name is already registered.
+func Register(name string, d Decoder) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := decoders[name]; ok {
+		panic("vendors: Register called twice for decoder " + name)
+	}
+	names = append(names, name)
+	decoders[name] = d
+}
+
+// Decoders returns the names of the registered decoders in registration order.
+func Decoders() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]string{}, names...)
+}
+
+// Decode returns the fields from all decoders matching the module.
+func Decode(m *Module) []Field {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	r := []Field{}
+	for _, n := range names {
+		if d := decoders[n]; d.Match(m) {
+			r = append(r, d.Decode(m)...)
+		}
+	}
+	return r
+}